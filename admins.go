@@ -0,0 +1,139 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const superusersCollection = "_superusers"
+
+// AdminModel is a superuser account (an admin before PocketBase 0.23).
+type AdminModel struct {
+	ID      string `json:"id"`
+	Email   string `json:"email"`
+	Created string `json:"created"`
+	Updated string `json:"updated"`
+}
+
+// AdminInput holds the fields to set when creating or updating a superuser.
+// Empty fields are left unchanged on update.
+type AdminInput struct {
+	Email    string
+	Password string
+}
+
+func (in AdminInput) payload() map[string]interface{} {
+	payload := map[string]interface{}{}
+	if in.Email != "" {
+		payload["email"] = in.Email
+	}
+	if in.Password != "" {
+		payload["password"] = in.Password
+		payload["passwordConfirm"] = in.Password
+	}
+	return payload
+}
+
+// adminsEndpoint returns the superusers collection records endpoint on
+// PocketBase 0.23+ and the legacy /api/admins endpoint on older servers. The
+// result is cached after the first successful probe.
+func (c *Client) adminsEndpoint(ctx context.Context, opts ...RequestOption) (string, error) {
+	c.mu.Lock()
+	endpoint := c.adminsPath
+	c.mu.Unlock()
+	if endpoint != "" {
+		return endpoint, nil
+	}
+
+	endpoint = "/api/collections/" + superusersCollection + "/records"
+	_, err := c.doRequest(ctx, "GET", endpoint, nil, append(opts[:len(opts):len(opts)], WithPerPage(1), WithSkipTotal())...)
+	if errors.Is(err, ErrNotFound) {
+		endpoint = "/api/admins"
+	} else if err != nil {
+		return "", fmt.Errorf("failed to detect superusers API: %w", err)
+	}
+
+	c.mu.Lock()
+	c.adminsPath = endpoint
+	c.mu.Unlock()
+	return endpoint, nil
+}
+
+// ListAdmins returns every superuser. Like the other superuser methods it
+// needs superuser credentials, e.g. WithAdminAuth().
+func (c *Client) ListAdmins(ctx context.Context, opts ...RequestOption) ([]AdminModel, error) {
+	endpoint, err := c.adminsEndpoint(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var admins []AdminModel
+	for page := 1; ; page++ {
+		pageOpts := append(opts[:len(opts):len(opts)], WithPage(page), WithPerPage(DefaultBatchSize))
+		respBody, err := c.doRequest(ctx, "GET", endpoint, nil, pageOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list admins: %w", err)
+		}
+
+		var list ListResult[AdminModel]
+		err = json.Unmarshal(respBody, &list)
+		if err != nil {
+			return nil, err
+		}
+		admins = append(admins, list.Items...)
+
+		if len(list.Items) < DefaultBatchSize || page >= list.TotalPages {
+			return admins, nil
+		}
+	}
+}
+
+func (c *Client) CreateAdmin(ctx context.Context, input AdminInput, opts ...RequestOption) (*AdminModel, error) {
+	endpoint, err := c.adminsEndpoint(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := c.doRequest(ctx, "POST", endpoint, input.payload(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create admin: %w", err)
+	}
+	return decodeAdmin(respBody)
+}
+
+func (c *Client) UpdateAdmin(ctx context.Context, id string, input AdminInput, opts ...RequestOption) (*AdminModel, error) {
+	endpoint, err := c.adminsEndpoint(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := c.doRequest(ctx, "PATCH", endpoint+"/"+id, input.payload(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update admin %s: %w", id, err)
+	}
+	return decodeAdmin(respBody)
+}
+
+func (c *Client) DeleteAdmin(ctx context.Context, id string, opts ...RequestOption) error {
+	endpoint, err := c.adminsEndpoint(ctx, opts...)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(ctx, "DELETE", endpoint+"/"+id, nil, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to delete admin %s: %w", id, err)
+	}
+	return nil
+}
+
+func decodeAdmin(respBody []byte) (*AdminModel, error) {
+	var admin AdminModel
+	err := json.Unmarshal(respBody, &admin)
+	if err != nil {
+		return nil, err
+	}
+	return &admin, nil
+}
@@ -0,0 +1,206 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// AuthResponse is returned by the auth-with-* endpoints.
+type AuthResponse struct {
+	Token  string          `json:"token"`
+	Record json.RawMessage `json:"record"`
+}
+
+// MFARequiredError is returned when the first authentication step succeeded
+// but the collection requires a second factor. Pass MFAID to the second auth
+// call (AuthWithPasswordMFA or AuthWithOTP) to complete the login.
+type MFARequiredError struct {
+	MFAID string
+	Err   *APIError
+}
+
+func (e *MFARequiredError) Error() string {
+	return "multi-factor authentication required (mfaId " + e.MFAID + ")"
+}
+
+func (e *MFARequiredError) Unwrap() error {
+	return e.Err
+}
+
+// AuthWithPassword authenticates against an auth collection and stores the
+// returned token on the client.
+func (c *Client) AuthWithPassword(ctx context.Context, collection, identity, password string) (*AuthResponse, error) {
+	return c.AuthWithPasswordMFA(ctx, collection, "", identity, password)
+}
+
+// AuthWithPasswordMFA is the second step of an MFA login using the password
+// method; mfaID comes from the MFARequiredError returned by the first step.
+func (c *Client) AuthWithPasswordMFA(ctx context.Context, collection, mfaID, identity, password string) (*AuthResponse, error) {
+	payload := map[string]interface{}{
+		"identity": identity,
+		"password": password,
+	}
+	if mfaID != "" {
+		payload["mfaId"] = mfaID
+	}
+	auth, err := c.authenticate(ctx, "/api/collections/"+collection+"/auth-with-password", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	c.setToken(auth.Token)
+	return auth, nil
+}
+
+// AdminAuthWithPassword authenticates a superuser and stores the token in
+// AdminToken, leaving the user Token untouched. Requests opt into the admin
+// token with WithAdminAuth. Servers older than PocketBase 0.23 are handled
+// through the legacy /api/admins route.
+func (c *Client) AdminAuthWithPassword(ctx context.Context, email, password string) (*AuthResponse, error) {
+	payload := map[string]interface{}{
+		"identity": email,
+		"password": password,
+	}
+	auth, err := c.authenticate(ctx, "/api/collections/"+superusersCollection+"/auth-with-password", payload)
+	if errors.Is(err, ErrNotFound) {
+		payload = map[string]interface{}{
+			"email":    email,
+			"password": password,
+		}
+		auth, err = c.authenticate(ctx, "/api/admins/auth-with-password", payload)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.AdminToken = auth.Token
+	return auth, nil
+}
+
+// RequestOTP sends a one-time password to email and returns the otpId needed
+// by AuthWithOTP.
+func (c *Client) RequestOTP(ctx context.Context, collection, email string) (string, error) {
+	endpoint := "/api/collections/" + collection + "/request-otp"
+	respBody, err := c.doRequest(ctx, "POST", endpoint, map[string]interface{}{"email": email})
+	if err != nil {
+		return "", fmt.Errorf("failed to request otp: %w", err)
+	}
+
+	var resp struct {
+		OTPID string `json:"otpId"`
+	}
+	err = json.Unmarshal(respBody, &resp)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.OTPID, nil
+}
+
+// AuthWithOTP authenticates with a one-time password. mfaID is optional and
+// only needed when OTP is used as the second factor of an MFA login.
+func (c *Client) AuthWithOTP(ctx context.Context, collection, otpID, password, mfaID string) (*AuthResponse, error) {
+	payload := map[string]interface{}{
+		"otpId":    otpID,
+		"password": password,
+	}
+	if mfaID != "" {
+		payload["mfaId"] = mfaID
+	}
+	auth, err := c.authenticate(ctx, "/api/collections/"+collection+"/auth-with-otp", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	c.setToken(auth.Token)
+	return auth, nil
+}
+
+func (c *Client) authenticate(ctx context.Context, endpoint string, payload map[string]interface{}) (*AuthResponse, error) {
+	respBody, err := c.doRequest(ctx, "POST", endpoint, payload)
+	if err != nil {
+		if mfaErr := asMFARequired(err); mfaErr != nil {
+			return nil, mfaErr
+		}
+		return nil, fmt.Errorf("failed to authenticate: %w", err)
+	}
+
+	var auth AuthResponse
+	err = json.Unmarshal(respBody, &auth)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth, nil
+}
+
+func asMFARequired(err error) *MFARequiredError {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusUnauthorized {
+		return nil
+	}
+
+	var body struct {
+		MFAID string `json:"mfaId"`
+	}
+	if json.Unmarshal(apiErr.Body, &body) != nil || body.MFAID == "" {
+		return nil
+	}
+	return &MFARequiredError{MFAID: body.MFAID, Err: apiErr}
+}
+
+// DeleteUser deletes a user record from an auth collection.
+func (c *Client) DeleteUser(ctx context.Context, collection, userID string) error {
+	err := c.DeleteRecord(ctx, collection, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	return nil
+}
+
+// DeleteCurrentUser deletes the record the client is authenticated as and
+// clears the stored token.
+func (c *Client) DeleteCurrentUser(ctx context.Context) error {
+	claims, err := DecodeToken(c.Token)
+	if err != nil {
+		return fmt.Errorf("failed to identify current user: %w", err)
+	}
+	if claims.CollectionID == "" || claims.IsAdmin() {
+		return errors.New("current token does not belong to an auth collection record")
+	}
+
+	err = c.DeleteUser(ctx, claims.CollectionID, claims.ID)
+	if err != nil {
+		return err
+	}
+
+	c.setToken("")
+	return nil
+}
+
+// Logout ends the session. If a revocation route was configured with
+// WithTokenRevocation it is called first (a 404 is ignored, for servers that
+// don't provide it); the token is then cleared and the OnAuthChange hook
+// fired even if revocation failed.
+func (c *Client) Logout(ctx context.Context) error {
+	var revokeErr error
+	if c.revokePath != "" && c.Token != "" {
+		_, err := c.doRequest(ctx, "POST", c.revokePath, nil)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			revokeErr = fmt.Errorf("failed to revoke token: %w", err)
+		}
+	}
+
+	c.setToken("")
+	return revokeErr
+}
+
+func (c *Client) setToken(token string) {
+	c.Token = token
+	if c.onAuthChange != nil {
+		c.onAuthChange(token)
+	}
+}
@@ -0,0 +1,103 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// BackupFileInfo describes a backup archive stored by PocketBase.
+type BackupFileInfo struct {
+	Key      string `json:"key"`
+	Size     int64  `json:"size"`
+	Modified string `json:"modified"`
+}
+
+// FileToken returns a short-lived token for accessing protected files and
+// downloading backups.
+func (c *Client) FileToken(ctx context.Context, opts ...RequestOption) (string, error) {
+	respBody, err := c.doRequest(ctx, "POST", "/api/files/token", nil, opts...)
+	if err != nil {
+		return "", fmt.Errorf("failed to get file token: %w", err)
+	}
+
+	var resp struct {
+		Token string `json:"token"`
+	}
+	err = json.Unmarshal(respBody, &resp)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Token, nil
+}
+
+// CreateBackup creates a new backup. An empty name lets PocketBase generate one.
+func (c *Client) CreateBackup(ctx context.Context, name string, opts ...RequestOption) error {
+	payload := map[string]interface{}{}
+	if name != "" {
+		payload["name"] = name
+	}
+	_, err := c.doRequest(ctx, "POST", "/api/backups", payload, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) ListBackups(ctx context.Context, opts ...RequestOption) ([]BackupFileInfo, error) {
+	respBody, err := c.doRequest(ctx, "GET", "/api/backups", nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	var backups []BackupFileInfo
+	err = json.Unmarshal(respBody, &backups)
+	if err != nil {
+		return nil, err
+	}
+
+	return backups, nil
+}
+
+// DownloadBackup streams the backup archive identified by key into w. The
+// file token required by PocketBase is fetched automatically.
+func (c *Client) DownloadBackup(ctx context.Context, key string, w io.Writer, opts ...RequestOption) error {
+	token, err := c.FileToken(ctx, opts...)
+	if err != nil {
+		return err
+	}
+
+	opts = append(opts[:len(opts):len(opts)], WithQueryParam("token", token))
+	resp, err := c.doStream(ctx, "GET", "/api/backups/"+url.PathEscape(key), nil, "", opts...)
+	if err != nil {
+		return fmt.Errorf("failed to download backup %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	_, err = io.Copy(w, resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to download backup %s: %w", key, err)
+	}
+	return nil
+}
+
+// RestoreBackup restores the instance from a backup. PocketBase restarts
+// once the restore completes.
+func (c *Client) RestoreBackup(ctx context.Context, key string, opts ...RequestOption) error {
+	_, err := c.doRequest(ctx, "POST", "/api/backups/"+url.PathEscape(key)+"/restore", nil, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to restore backup %s: %w", key, err)
+	}
+	return nil
+}
+
+func (c *Client) DeleteBackup(ctx context.Context, key string, opts ...RequestOption) error {
+	_, err := c.doRequest(ctx, "DELETE", "/api/backups/"+url.PathEscape(key), nil, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to delete backup %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// DefaultBatchAPISize matches PocketBase's default limit of requests per
+// /api/batch call (Settings.Batch.MaxRequests).
+const DefaultBatchAPISize = 50
+
+// WithBatchAPI makes CreateMultipleRecords send records through the
+// transactional /api/batch endpoint (PocketBase 0.23+), size records per
+// call, instead of one request per record. Batches are sent one after the
+// other and each is atomic: when one record fails, none of its batch is
+// created. A size of 0 means DefaultBatchAPISize.
+func WithBatchAPI(size int) RequestOption {
+	return func(o *requestOptions) {
+		o.batchAPI = true
+		o.batchSize = size
+	}
+}
+
+// Batch collects record operations across collections and submits them as
+// one atomic /api/batch request. The batch API must be enabled in the
+// instance settings (Settings.Batch).
+type Batch struct {
+	client   *Client
+	requests []batchRequest
+}
+
+type batchRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Body   interface{} `json:"body,omitempty"`
+}
+
+// BatchResult is the response to a single step of a batch.
+type BatchResult struct {
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// Batch starts an empty batch.
+func (c *Client) Batch() *Batch {
+	return &Batch{client: c}
+}
+
+func (b *Batch) Create(collection string, record map[string]interface{}) *Batch {
+	return b.add("POST", "/api/collections/"+collection+"/records", record)
+}
+
+func (b *Batch) Update(collection, id string, record map[string]interface{}) *Batch {
+	return b.add("PATCH", "/api/collections/"+collection+"/records/"+id, record)
+}
+
+// Upsert updates the record with record["id"] or creates it when no such
+// record exists.
+func (b *Batch) Upsert(collection string, record map[string]interface{}) *Batch {
+	return b.add("PUT", "/api/collections/"+collection+"/records", record)
+}
+
+func (b *Batch) Delete(collection, id string) *Batch {
+	return b.add("DELETE", "/api/collections/"+collection+"/records/"+id, nil)
+}
+
+// Len returns the number of steps in the batch.
+func (b *Batch) Len() int {
+	return len(b.requests)
+}
+
+func (b *Batch) add(method, url string, body interface{}) *Batch {
+	b.requests = append(b.requests, batchRequest{Method: method, URL: url, Body: body})
+	return b
+}
+
+// Send submits the batch. Results are in the order the steps were added.
+// If any step fails the whole batch is rolled back and the error is a
+// *BatchError.
+func (b *Batch) Send(ctx context.Context, opts ...RequestOption) ([]BatchResult, error) {
+	payload := map[string]interface{}{"requests": b.requests}
+	respBody, err := b.client.doRequest(ctx, "POST", "/api/batch", payload, opts...)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			return nil, newBatchError(apiErr)
+		}
+		return nil, fmt.Errorf("failed to send batch: %w", err)
+	}
+
+	var results []BatchResult
+	if err := json.Unmarshal(respBody, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// BatchError is returned when a batch is rejected. Steps maps the index of
+// each failed step to its error; it is empty when the whole request was
+// rejected, e.g. because the batch API is disabled.
+type BatchError struct {
+	Err   *APIError
+	Steps map[int]*APIError
+}
+
+func (e *BatchError) Error() string {
+	first := -1
+	for i := range e.Steps {
+		if first < 0 || i < first {
+			first = i
+		}
+	}
+	if first < 0 {
+		return "batch failed: " + e.Err.Error()
+	}
+	return fmt.Sprintf("batch failed at step %d: %v", first, e.Steps[first])
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+func newBatchError(apiErr *APIError) *BatchError {
+	batchErr := &BatchError{Err: apiErr, Steps: make(map[int]*APIError)}
+
+	var payload struct {
+		Data struct {
+			Requests map[string]struct {
+				Response json.RawMessage `json:"response"`
+			} `json:"requests"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(apiErr.Body, &payload); err != nil {
+		return batchErr
+	}
+	for key, step := range payload.Data.Requests {
+		i, err := strconv.Atoi(key)
+		if err != nil {
+			continue
+		}
+
+		status := apiErr.Status
+		var response struct {
+			Status int `json:"status"`
+		}
+		if json.Unmarshal(step.Response, &response) == nil && response.Status != 0 {
+			status = response.Status
+		}
+		batchErr.Steps[i] = newAPIError(status, step.Response)
+	}
+	return batchErr
+}
+
+// createViaBatch is CreateMultipleRecords over the batch API.
+func (c *Client) createViaBatch(ctx context.Context, collection string, records []map[string]interface{}, o *requestOptions, opts []RequestOption, result *BulkOperationResult) error {
+	size := o.batchSize
+	if size <= 0 {
+		size = DefaultBatchAPISize
+	}
+
+	result.progress = o.progress
+	stopped := false
+	for start := 0; start < len(records); start += size {
+		end := start + size
+		if end > len(records) {
+			end = len(records)
+		}
+
+		if err := ctx.Err(); err != nil || stopped {
+			if err == nil {
+				err = context.Canceled
+			}
+			for i := start; i < end; i++ {
+				result.failed(i, "", 0, err)
+			}
+			continue
+		}
+
+		batch := c.Batch()
+		for _, record := range records[start:end] {
+			batch.Create(collection, record)
+		}
+
+		var results []BatchResult
+		attempts, err := c.retryItem(ctx, o, "POST", func() (err error) {
+			sendCtx := ctx
+			if o.itemRetry != nil {
+				sendCtx = context.WithValue(ctx, skipClientRetryKey{}, true)
+			}
+			results, err = batch.Send(sendCtx, opts...)
+			return err
+		})
+		if err != nil {
+			var batchErr *BatchError
+			errors.As(err, &batchErr)
+			for i := start; i < end; i++ {
+				itemErr := err
+				if batchErr != nil && batchErr.Steps[i-start] != nil {
+					itemErr = batchErr.Steps[i-start]
+				}
+				result.failed(i, "", attempts, itemErr)
+			}
+			stopped = o.failFast
+			continue
+		}
+
+		for i, r := range results {
+			result.succeeded(start+i, recordID(r.Body), r.Body)
+		}
+	}
+	return result.err(ctx, o)
+}
@@ -0,0 +1,319 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultBulkConcurrency is the number of parallel requests bulk operations
+// make unless WithConcurrency says otherwise.
+const DefaultBulkConcurrency = 10
+
+// WithConcurrency sets how many requests a bulk operation runs in parallel.
+func WithConcurrency(n int) RequestOption {
+	return func(o *requestOptions) {
+		o.concurrency = n
+	}
+}
+
+// WithItemRetry retries each failed item of a bulk operation according to
+// policy, independently of the other items. As with the client's retry
+// policy, creates are only retried on 429 unless RetryNonIdempotent is set,
+// and Retry-After is honoured. With WithBatchAPI, a whole batch is retried.
+// The policy replaces the client's WithRetryPolicy for the items, so the two
+// never multiply the number of requests.
+func WithItemRetry(policy RetryPolicy) RequestOption {
+	return func(o *requestOptions) {
+		o.itemRetry = &policy
+	}
+}
+
+// BulkError is the failure of a single item of a bulk operation. Index is
+// the item's position in the input; Attempts is the number of requests made
+// for it, which is 0 when the item was never sent.
+type BulkError struct {
+	Index    int
+	ID       string
+	Attempts int
+	Err      error
+}
+
+func (e *BulkError) Error() string {
+	var attempts string
+	if e.Attempts > 1 {
+		attempts = fmt.Sprintf(" after %d attempts", e.Attempts)
+	}
+	if e.ID != "" {
+		return fmt.Sprintf("item %d (%s)%s: %v", e.Index, e.ID, attempts, e.Err)
+	}
+	return fmt.Sprintf("item %d%s: %v", e.Index, attempts, e.Err)
+}
+
+func (e *BulkError) Unwrap() error {
+	return e.Err
+}
+
+// BulkOperationResult reports a bulk operation. Results has one entry per
+// input item, in input order. IDs holds the IDs of the records that
+// succeeded and Errors the failures, both in completion order.
+type BulkOperationResult struct {
+	Results []BulkItemResult
+	IDs     []string
+	Errors  []*BulkError
+
+	mu sync.Mutex
+	// notify, when set, receives every item as it completes.
+	notify   func(BulkItemResult)
+	progress func(done, total int)
+	done     int
+}
+
+// BulkItemResult is the outcome of one input item. Record holds the record
+// returned by the server; it is empty for deletes.
+type BulkItemResult struct {
+	Index  int
+	ID     string
+	Record json.RawMessage
+	Err    error
+}
+
+func newBulkResult(n int) *BulkOperationResult {
+	return &BulkOperationResult{Results: make([]BulkItemResult, n)}
+}
+
+func (r *BulkOperationResult) succeeded(index int, id string, record json.RawMessage) {
+	item := BulkItemResult{Index: index, ID: id, Record: record}
+	r.mu.Lock()
+	r.Results[index] = item
+	r.IDs = append(r.IDs, id)
+	r.completed()
+	r.mu.Unlock()
+
+	if r.notify != nil {
+		r.notify(item)
+	}
+}
+
+func (r *BulkOperationResult) failed(index int, id string, attempts int, err error) {
+	item := BulkItemResult{Index: index, ID: id, Err: err}
+	r.mu.Lock()
+	r.Results[index] = item
+	r.Errors = append(r.Errors, &BulkError{Index: index, ID: id, Attempts: attempts, Err: err})
+	r.completed()
+	r.mu.Unlock()
+
+	if r.notify != nil {
+		r.notify(item)
+	}
+}
+
+// completed counts a finished item and reports progress. r.mu must be held,
+// which also keeps progress calls from overlapping.
+func (r *BulkOperationResult) completed() {
+	r.done++
+	if r.progress != nil {
+		r.progress(r.done, len(r.Results))
+	}
+}
+
+// streamBulk runs a bulk operation in the background and delivers each
+// item on the returned channel as it completes. The channel is closed when
+// the operation is done. Once ctx is done, undelivered items are dropped.
+func streamBulk(ctx context.Context, n int, run func(result *BulkOperationResult)) <-chan BulkItemResult {
+	ch := make(chan BulkItemResult, DefaultBulkConcurrency)
+	go func() {
+		defer close(ch)
+		result := newBulkResult(n)
+		result.notify = func(item BulkItemResult) {
+			select {
+			case ch <- item:
+			case <-ctx.Done():
+			}
+		}
+		run(result)
+	}()
+	return ch
+}
+
+// WithProgress calls fn after every item of a bulk operation with the
+// number of items done so far and the total. Calls never overlap.
+func WithProgress(fn func(done, total int)) RequestOption {
+	return func(o *requestOptions) {
+		o.progress = fn
+	}
+}
+
+// WithFailFast makes a bulk operation stop after the first failed item:
+// requests in flight are cancelled and items not yet started are reported
+// with context.Canceled. The operation then returns its partial result
+// together with an error wrapping the first failure.
+func WithFailFast() RequestOption {
+	return func(o *requestOptions) {
+		o.failFast = true
+	}
+}
+
+// CreateMultipleRecords creates records in parallel, or through the batch
+// API with WithBatchAPI. Per-record failures are reported in the result
+// rather than as the returned error, unless WithFailFast is given. Items not
+// started when ctx is done are reported with the context's error.
+func (c *Client) CreateMultipleRecords(ctx context.Context, collection string, records []map[string]interface{}, opts ...RequestOption) (*BulkOperationResult, error) {
+	result := newBulkResult(len(records))
+	return result, c.createMultiple(ctx, collection, records, opts, result)
+}
+
+// CreateMultipleRecordsStream is CreateMultipleRecords delivering each
+// item's result on the returned channel as soon as it completes, so large
+// jobs can report progress and persist results incrementally. The channel
+// is closed when all records are done. The consumer must drain it or
+// cancel ctx, which stops the operation and drops undelivered results.
+func (c *Client) CreateMultipleRecordsStream(ctx context.Context, collection string, records []map[string]interface{}, opts ...RequestOption) <-chan BulkItemResult {
+	return streamBulk(ctx, len(records), func(result *BulkOperationResult) {
+		c.createMultiple(ctx, collection, records, opts, result)
+	})
+}
+
+func (c *Client) createMultiple(ctx context.Context, collection string, records []map[string]interface{}, opts []RequestOption, result *BulkOperationResult) error {
+	if o := newRequestOptions(opts); o.batchAPI {
+		return c.createViaBatch(ctx, collection, records, o, opts, result)
+	}
+
+	endpoint := "/api/collections/" + collection + "/records"
+	return c.runBulk(ctx, "POST", result, opts, func(ctx context.Context, i int) (string, json.RawMessage, error) {
+		respBody, err := c.doRequest(ctx, "POST", endpoint, records[i], opts...)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to create record: %w", err)
+		}
+		return recordID(respBody), respBody, nil
+	})
+}
+
+// recordID extracts the id of a record response.
+func recordID(record json.RawMessage) string {
+	var r struct {
+		ID string `json:"id"`
+	}
+	// A record that can't be read was still written; report it without an
+	// ID rather than as a failure.
+	_ = json.Unmarshal(record, &r)
+	return r.ID
+}
+
+// runBulk calls op for each of n items with the concurrency, retry and
+// fail-fast behaviour selected by opts. op returns the ID and the server's
+// response for the affected record; method is the HTTP method it uses.
+func (c *Client) runBulk(ctx context.Context, method string, result *BulkOperationResult, opts []RequestOption, op func(ctx context.Context, i int) (string, json.RawMessage, error)) error {
+	o := newRequestOptions(opts)
+	opCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	result.progress = o.progress
+
+	forEachConcurrent(bulkConcurrency(o), len(result.Results), func(i int) {
+		if err := opCtx.Err(); err != nil {
+			result.failed(i, "", 0, err)
+			return
+		}
+
+		var id string
+		var record json.RawMessage
+		attempts, err := c.retryItem(opCtx, o, method, func() (err error) {
+			itemCtx := opCtx
+			if o.itemRetry != nil {
+				itemCtx = context.WithValue(opCtx, skipClientRetryKey{}, true)
+			}
+			id, record, err = op(itemCtx, i)
+			return err
+		})
+		if err != nil {
+			result.failed(i, id, attempts, err)
+			if o.failFast {
+				cancel()
+			}
+			return
+		}
+		result.succeeded(i, id, record)
+	})
+	return result.err(ctx, o)
+}
+
+// retryItem calls fn until it succeeds or WithItemRetry's policy gives up,
+// and returns the number of attempts. An open circuit breaker is not
+// retried: it fails without contacting the server until it closes.
+func (c *Client) retryItem(ctx context.Context, o *requestOptions, method string, fn func() error) (int, error) {
+	policy := o.itemRetry
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || policy == nil || attempt >= policy.MaxAttempts || ctx.Err() != nil || errors.Is(err, ErrCircuitOpen) || !policy.retryable(method, err) {
+			return attempt, err
+		}
+
+		var retryAfter time.Duration
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			retryAfter = apiErr.RetryAfter
+		}
+		select {
+		case <-ctx.Done():
+			return attempt, err
+		case <-c.after(policy.delay(attempt, retryAfter)):
+		}
+	}
+}
+
+// skipClientRetryKey marks the context of a bulk item retried by
+// WithItemRetry, so the client's retry policy does not retry it as well.
+type skipClientRetryKey struct{}
+
+func skipClientRetry(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipClientRetryKey{}).(bool)
+	return skip
+}
+
+// err is the error a bulk operation returns alongside its result.
+func (r *BulkOperationResult) err(ctx context.Context, o *requestOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if o.failFast && len(r.Errors) > 0 {
+		return fmt.Errorf("bulk operation stopped: %w", r.Errors[0])
+	}
+	return nil
+}
+
+func bulkConcurrency(o *requestOptions) int {
+	if o.concurrency > 0 {
+		return o.concurrency
+	}
+	return DefaultBulkConcurrency
+}
+
+// forEachConcurrent calls fn for every index in [0, n) using at most limit
+// goroutines at a time, and waits for all calls to return. A limit below 2
+// runs the calls sequentially.
+func forEachConcurrent(limit, n int, fn func(i int)) {
+	if limit < 2 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
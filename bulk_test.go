@@ -0,0 +1,97 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// recordingClock fires timers immediately and records their durations.
+type recordingClock struct {
+	mu     sync.Mutex
+	delays []time.Duration
+}
+
+func (c *recordingClock) Now() time.Time { return time.Now() }
+
+func (c *recordingClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	c.delays = append(c.delays, d)
+	c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	ch <- time.Now()
+	return ch
+}
+
+func TestItemRetryHonoursRetryAfterWithoutClientRetries(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"status":429,"message":"Too many requests."}`))
+			return
+		}
+		w.Write([]byte(`{"id":"abc"}`))
+	}))
+	defer srv.Close()
+
+	clock := &recordingClock{}
+	client := NewClient(srv.URL, "", WithClock(clock), WithRetryPolicy(DefaultRetryPolicy()))
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Second, RetryableStatuses: []int{http.StatusTooManyRequests}}
+
+	result, err := client.CreateMultipleRecords(context.Background(), "tasks", []map[string]interface{}{{"title": "a"}}, WithItemRetry(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("got %d requests, want 2", got)
+	}
+	if len(clock.delays) != 1 || clock.delays[0] != 2*time.Second {
+		t.Errorf("delays = %v, want [2s]", clock.delays)
+	}
+}
+
+func TestItemRetryWithBatchAPIWithoutClientRetries(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Retry-After", "2")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"status":429,"message":"Too many requests."}`))
+	}))
+	defer srv.Close()
+
+	clock := &recordingClock{}
+	client := NewClient(srv.URL, "", WithClock(clock), WithRetryPolicy(DefaultRetryPolicy()))
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Second, RetryableStatuses: []int{http.StatusTooManyRequests}}
+
+	result, _ := client.CreateMultipleRecords(context.Background(), "tasks", []map[string]interface{}{{"title": "a"}}, WithBatchAPI(0), WithItemRetry(policy))
+	if result == nil || len(result.Errors) != 1 {
+		t.Fatalf("result = %+v, want the item to fail", result)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("got %d requests, want 3 (one per item attempt)", got)
+	}
+	if len(clock.delays) != 2 || clock.delays[0] != 2*time.Second {
+		t.Errorf("delays = %v, want [2s 2s]", clock.delays)
+	}
+}
+
+func TestItemRetryStopsOnOpenCircuit(t *testing.T) {
+	calls := 0
+	attempts, _ := (&Client{}).retryItem(context.Background(), &requestOptions{itemRetry: &RetryPolicy{MaxAttempts: 5}}, "GET", func() error {
+		calls++
+		return ErrCircuitOpen
+	})
+	if attempts != 1 || calls != 1 {
+		t.Errorf("got %d attempts, want 1", attempts)
+	}
+}
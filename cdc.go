@@ -0,0 +1,283 @@
+package gopocketbaseclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ChangeEvent is a record change forwarded by RunCDC.
+type ChangeEvent struct {
+	Collection string          `json:"collection"`
+	Action     string          `json:"action"`
+	RecordID   string          `json:"recordId"`
+	Updated    string          `json:"updated"`
+	Record     json.RawMessage `json:"record"`
+}
+
+// Sink receives change events. Returning an error makes RunCDC retry the
+// same event, so sinks must tolerate duplicates (at-least-once delivery).
+type Sink interface {
+	Deliver(ctx context.Context, event ChangeEvent) error
+}
+
+// SinkFunc adapts a function to a Sink, e.g. to publish to NATS or Kafka.
+type SinkFunc func(ctx context.Context, event ChangeEvent) error
+
+func (f SinkFunc) Deliver(ctx context.Context, event ChangeEvent) error {
+	return f(ctx, event)
+}
+
+// ChannelSink forwards events to ch.
+func ChannelSink(ch chan<- ChangeEvent) Sink {
+	return SinkFunc(func(ctx context.Context, event ChangeEvent) error {
+		select {
+		case ch <- event:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// WebhookSink POSTs every event as JSON to url; any non-2xx response is
+// treated as a failed delivery. A nil httpClient uses http.DefaultClient.
+func WebhookSink(url string, httpClient *http.Client) Sink {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return SinkFunc(func(ctx context.Context, event ChangeEvent) error {
+		body, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("webhook returned HTTP %d", resp.StatusCode)
+		}
+		return nil
+	})
+}
+
+// CheckpointStore persists, per collection, the "updated" timestamp of the
+// last delivered change so RunCDC can catch up after a restart.
+type CheckpointStore interface {
+	Load(ctx context.Context, key string) (string, error)
+	Save(ctx context.Context, key string, checkpoint string) error
+}
+
+// MemoryCheckpointStore is an in-process CheckpointStore.
+type MemoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]string
+}
+
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{checkpoints: make(map[string]string)}
+}
+
+func (s *MemoryCheckpointStore) Load(ctx context.Context, key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checkpoints[key], nil
+}
+
+func (s *MemoryCheckpointStore) Save(ctx context.Context, key string, checkpoint string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoints[key] = checkpoint
+	return nil
+}
+
+// CDCConfig configures RunCDC.
+type CDCConfig struct {
+	Collections []string
+	Sink        Sink
+	// Checkpoints enables catch-up: on start and after every realtime
+	// reconnect, records updated since the last checkpoint are replayed as
+	// "update" events. Deletes that happened while RunCDC was not running or
+	// not connected cannot be recovered.
+	Checkpoints CheckpointStore
+	// Subscribe narrows the realtime subscriptions (filter, expand, ...).
+	Subscribe *SubscribeOptions
+	// RetryDelay is the pause between failed deliveries (default 1s).
+	RetryDelay time.Duration
+	// MaxRetries limits delivery attempts per event; 0 retries forever.
+	MaxRetries int
+}
+
+// ErrCDCOverflow is returned by RunCDC when realtime events arrive faster
+// than the sink accepts them and the event queue is full. Events were
+// dropped; with Checkpoints, running RunCDC again catches up on them.
+var ErrCDCOverflow = errors.New("cdc: event queue overflowed")
+
+// cdcQueueSize bounds the events buffered between the realtime connection
+// and the sink.
+const cdcQueueSize = 1024
+
+// RunCDC streams changes of the configured collections to cfg.Sink until ctx
+// is done. Events are delivered in order, one at a time, and retried until
+// the sink accepts them. A slow sink never blocks the client's realtime
+// connection: once the queue is full RunCDC returns ErrCDCOverflow.
+func (c *Client) RunCDC(ctx context.Context, cfg CDCConfig) error {
+	if cfg.Sink == nil {
+		return errors.New("cdc: no sink configured")
+	}
+	if len(cfg.Collections) == 0 {
+		return errors.New("cdc: no collections configured")
+	}
+	if cfg.RetryDelay <= 0 {
+		cfg.RetryDelay = time.Second
+	}
+
+	queue := make(chan ChangeEvent, cdcQueueSize)
+	overflow := make(chan struct{})
+	var overflowOnce sync.Once
+	for _, collection := range cfg.Collections {
+		unsubscribe, err := c.Subscribe(ctx, collection+"/*", cfg.Subscribe, func(e RecordEvent) {
+			select {
+			case queue <- newChangeEvent(collection, e.Action, e.Record):
+			default:
+				overflowOnce.Do(func() { close(overflow) })
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("cdc: failed to subscribe to %s: %w", collection, err)
+		}
+		defer unsubscribe()
+	}
+
+	reconnected := make(chan struct{}, 1)
+	if cfg.Checkpoints != nil {
+		removeHook := c.realtimeConn().onConnect(func() {
+			select {
+			case reconnected <- struct{}{}:
+			default:
+			}
+		})
+		defer removeHook()
+	}
+
+	checkpoints := make(map[string]string)
+	if cfg.Checkpoints != nil {
+		for _, collection := range cfg.Collections {
+			checkpoint, err := cfg.Checkpoints.Load(ctx, collection)
+			if err != nil {
+				return fmt.Errorf("cdc: failed to load checkpoint for %s: %w", collection, err)
+			}
+			checkpoints[collection] = checkpoint
+		}
+		if err := c.cdcCatchUpAll(ctx, cfg, checkpoints); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-overflow:
+			return ErrCDCOverflow
+		case <-reconnected:
+			if err := c.cdcCatchUpAll(ctx, cfg, checkpoints); err != nil {
+				return err
+			}
+		case event := <-queue:
+			if err := c.cdcDeliver(ctx, cfg, event, checkpoints); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// cdcCatchUpAll replays the changes missed since the checkpoints of all
+// collections that have one.
+func (c *Client) cdcCatchUpAll(ctx context.Context, cfg CDCConfig, checkpoints map[string]string) error {
+	for _, collection := range cfg.Collections {
+		if checkpoints[collection] == "" {
+			continue
+		}
+		if err := c.cdcCatchUp(ctx, cfg, collection, checkpoints); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) cdcCatchUp(ctx context.Context, cfg CDCConfig, collection string, checkpoints map[string]string) error {
+	filter := BuildFilter("updated >= {:since}", map[string]interface{}{"since": checkpoints[collection]})
+	if cfg.Subscribe != nil && cfg.Subscribe.Filter != "" {
+		filter = "(" + filter + ") && (" + cfg.Subscribe.Filter + ")"
+	}
+
+	var deliverErr error
+	c.Iterate(ctx, collection, WithFilter(filter), WithSort("updated"))(func(record json.RawMessage, err error) bool {
+		if err != nil {
+			deliverErr = fmt.Errorf("cdc: failed to catch up %s: %w", collection, err)
+			return false
+		}
+		deliverErr = c.cdcDeliver(ctx, cfg, newChangeEvent(collection, "update", record), checkpoints)
+		return deliverErr == nil
+	})
+	return deliverErr
+}
+
+func (c *Client) cdcDeliver(ctx context.Context, cfg CDCConfig, event ChangeEvent, checkpoints map[string]string) error {
+	for attempt := 1; ; attempt++ {
+		err := cfg.Sink.Deliver(ctx, event)
+		if err == nil {
+			break
+		}
+		if cfg.MaxRetries > 0 && attempt >= cfg.MaxRetries {
+			return fmt.Errorf("cdc: failed to deliver %s %s/%s after %d attempts: %w", event.Action, event.Collection, event.RecordID, attempt, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.after(cfg.RetryDelay):
+		}
+	}
+
+	if cfg.Checkpoints == nil || event.Updated <= checkpoints[event.Collection] {
+		return nil
+	}
+	if err := cfg.Checkpoints.Save(ctx, event.Collection, event.Updated); err != nil {
+		return fmt.Errorf("cdc: failed to save checkpoint for %s: %w", event.Collection, err)
+	}
+	checkpoints[event.Collection] = event.Updated
+	return nil
+}
+
+func newChangeEvent(collection, action string, record json.RawMessage) ChangeEvent {
+	var meta struct {
+		ID      string `json:"id"`
+		Updated string `json:"updated"`
+	}
+	_ = json.Unmarshal(record, &meta)
+
+	return ChangeEvent{
+		Collection: collection,
+		Action:     action,
+		RecordID:   meta.ID,
+		Updated:    meta.Updated,
+		Record:     record,
+	}
+}
@@ -0,0 +1,137 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// CircuitBreaker configures the client's circuit breaker, enabled with
+// WithCircuitBreaker. After FailureThreshold consecutive failures (network
+// errors and 5xx responses) the circuit opens and requests fail immediately
+// with ErrCircuitOpen. Once OpenDuration has passed, HalfOpenProbes requests
+// are let through; the circuit closes when they all succeed and opens again
+// as soon as one fails.
+type CircuitBreaker struct {
+	// FailureThreshold defaults to 5.
+	FailureThreshold int
+	// OpenDuration defaults to 30 seconds.
+	OpenDuration time.Duration
+	// HalfOpenProbes defaults to 1.
+	HalfOpenProbes int
+}
+
+// WithCircuitBreaker makes the client fail fast while PocketBase is down,
+// instead of tying up goroutines in timeouts and retries.
+func WithCircuitBreaker(cfg CircuitBreaker) ClientOption {
+	return func(c *Client) {
+		if cfg.FailureThreshold <= 0 {
+			cfg.FailureThreshold = 5
+		}
+		if cfg.OpenDuration <= 0 {
+			cfg.OpenDuration = 30 * time.Second
+		}
+		if cfg.HalfOpenProbes <= 0 {
+			cfg.HalfOpenProbes = 1
+		}
+		c.breaker = &circuitBreaker{cfg: cfg}
+	}
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+type circuitBreaker struct {
+	cfg CircuitBreaker
+
+	mu        sync.Mutex
+	state     circuitState
+	failures  int
+	openedAt  time.Time
+	probes    int
+	successes int
+}
+
+// allow reports whether a request may be sent now. In the half-open state
+// it claims one of the probes, which record gives back.
+func (b *circuitBreaker) allow(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == circuitOpen {
+		if now.Sub(b.openedAt) < b.cfg.OpenDuration {
+			return ErrCircuitOpen
+		}
+		b.state = circuitHalfOpen
+		b.probes, b.successes = 0, 0
+	}
+	if b.state == circuitHalfOpen {
+		if b.probes >= b.cfg.HalfOpenProbes {
+			return ErrCircuitOpen
+		}
+		b.probes++
+	}
+	return nil
+}
+
+// record updates the breaker with the outcome of a request allow let
+// through. Errors that say nothing about the server's health, such as 4xx
+// responses or a canceled context, only release the probe.
+func (b *circuitBreaker) record(now time.Time, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	failed := serverFailure(err)
+	switch b.state {
+	case circuitClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.cfg.FailureThreshold {
+			b.open(now)
+		}
+	case circuitHalfOpen:
+		if failed {
+			b.open(now)
+			return
+		}
+		if err != nil {
+			b.probes--
+			return
+		}
+		b.successes++
+		if b.successes >= b.cfg.HalfOpenProbes {
+			b.state = circuitClosed
+			b.failures = 0
+		}
+	}
+}
+
+func (b *circuitBreaker) open(now time.Time) {
+	b.state = circuitOpen
+	b.openedAt = now
+	b.failures = 0
+}
+
+// serverFailure reports whether err shows that PocketBase is unreachable or
+// failing: a 5xx response or a transport error other than cancellation.
+func serverFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status >= 500
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
@@ -2,63 +2,313 @@ package gopocketbaseclient
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
-func NewClient(baseURL, jwtToken string) *Client {
-	return &Client{
-		BaseURL: baseURL,
+// NewClient returns a client for the PocketBase instance at baseURL, which
+// may include a path prefix (https://example.com/pb). An invalid baseURL is
+// reported by Err and by every request.
+func NewClient(baseURL, jwtToken string, opts ...ClientOption) *Client {
+	normalized, err := normalizeBaseURL(baseURL)
+	if err != nil {
+		normalized = baseURL
+	}
+	c := &Client{
+		BaseURL: normalized,
+		err:     err,
 		HTTPClient: &http.Client{
 			Timeout: time.Second * 10,
 		},
-		Token: jwtToken,
+		Token:     jwtToken,
+		clock:     SystemClock{},
+		userAgent: defaultUserAgent(),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
-func (c *Client) doRequest(method, endpoint string, body interface{}) ([]byte, error) {
+// Err returns the error found in the arguments to NewClient, if any.
+func (c *Client) Err() error {
+	return c.err
+}
+
+// normalizeBaseURL checks that raw is an absolute http(s) URL without query
+// or fragment and strips trailing slashes, so endpoints can be appended.
+func normalizeBaseURL(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidBaseURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("%w: scheme must be http or https, got %q", ErrInvalidBaseURL, raw)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("%w: missing host in %q", ErrInvalidBaseURL, raw)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("%w: %q must not have a query or fragment", ErrInvalidBaseURL, raw)
+	}
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = strings.TrimRight(u.RawPath, "/")
+	return u.String(), nil
+}
+
+// url returns the absolute URL of endpoint. BaseURL is trimmed again since
+// it may have been changed after NewClient.
+func (c *Client) url(endpoint string) string {
+	return strings.TrimRight(c.BaseURL, "/") + endpoint
+}
+
+// Send calls an arbitrary PocketBase route (custom hooks, plugins,
+// /api/health, ...) using the client's auth, transport and error handling.
+func (c *Client) Send(ctx context.Context, method, path string, body interface{}, query url.Values, opts ...RequestOption) ([]byte, error) {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return c.doRequest(ctx, method, withQuery(path, query), body, opts...)
+}
+
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, body interface{}, opts ...RequestOption) ([]byte, error) {
 	var reqBody []byte
 	var err error
 	if body != nil {
-		reqBody, err = json.Marshal(body)
+		reqBody, err = c.marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
 	}
 
-	req, err := http.NewRequest(method, c.BaseURL+endpoint, bytes.NewBuffer(reqBody))
+	o := newRequestOptions(opts)
+	if o.err != nil {
+		return nil, o.err
+	}
+	o.setRequestID(ctx)
+	endpoint = withQuery(endpoint, o.query)
+
+	if c.cache == nil {
+		return c.roundTrip(ctx, method, endpoint, reqBody, o)
+	}
+	if method == "GET" {
+		return c.cachedRequest(ctx, endpoint, o, func(ctx context.Context) ([]byte, error) {
+			return c.roundTrip(ctx, method, endpoint, reqBody, o)
+		})
+	}
+
+	// A failed write may still have been applied, so evict either way.
+	defer c.invalidateAfterWrite(endpoint)
+	return c.roundTrip(ctx, method, endpoint, reqBody, o)
+}
+
+// roundTrip sends a request, retrying it according to the client's policy.
+func (c *Client) roundTrip(ctx context.Context, method, endpoint string, reqBody []byte, o *requestOptions) ([]byte, error) {
+	policy := c.retryPolicy
+	for attempt := 1; ; attempt++ {
+		respBody, header, err := c.send(ctx, method, endpoint, reqBody, o)
+		if err == nil {
+			return respBody, nil
+		}
+		if policy == nil || skipClientRetry(ctx) || attempt >= policy.MaxAttempts || ctx.Err() != nil || errors.Is(err, ErrCircuitOpen) || !policy.retryable(method, err) {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-c.after(policy.delay(attempt, c.retryAfter(header))):
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, endpoint string, reqBody []byte, o *requestOptions) (respBody []byte, header http.Header, err error) {
+	var status int
+	if c.logger != nil {
+		start := c.now()
+		defer func() {
+			c.logRequest(ctx, method, endpoint, o.requestID(), status, reqBody, respBody, err, c.now().Sub(start))
+		}()
+	}
+
+	if c.breaker != nil {
+		if err := c.breaker.allow(c.now()); err != nil {
+			return nil, nil, err
+		}
+		defer func() {
+			c.breaker.record(c.now(), err)
+		}()
+	}
+
+	req, err := c.newRequest(ctx, method, endpoint, bytes.NewReader(reqBody), o)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.Token)
+	if dump := c.dumpFunc(o); dump != nil {
+		curl := curlCommand(req, reqBody)
+		defer func() {
+			d := RequestDump{Curl: curl, Status: status, ResponseBody: respBody, Err: err}
+			var apiErr *APIError
+			if errors.As(err, &apiErr) {
+				d.ResponseBody = apiErr.Body
+			}
+			dump(d)
+		}()
+	}
+
+	var validated *validatedResponse
+	var validatorKey string
+	if c.validators != nil && method == "GET" {
+		validatorKey = req.Header.Get("Authorization") + " " + endpoint
+		if e, ok := c.validators.get(validatorKey); ok {
+			validated = e
+			validated.setConditional(req)
+		}
+	}
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.httpClientFor(o).Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, nil, fmt.Errorf("request %s failed: %w", o.requestID(), err)
 	}
 	defer resp.Body.Close()
+	status = resp.StatusCode
 
-	respBody, err := io.ReadAll(resp.Body)
+	body, err := decodedBody(resp)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, resp.Header, err
 	}
+	respBody, err = readBody(body)
+	if err != nil {
+		return nil, resp.Header, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotModified && validated != nil {
+		return bytes.Clone(validated.body), resp.Header, nil
+	}
+	if err := checkHTTPStatus(resp.StatusCode, respBody, o.requestID()); err != nil {
+		err.(*APIError).RetryAfter = c.retryAfter(resp.Header)
+		return nil, resp.Header, err
+	}
+
+	if c.validators != nil && method == "GET" {
+		c.validators.store(validatorKey, resp.Header, respBody)
+	}
+	return respBody, resp.Header, nil
+}
+
+func (c *Client) newRequest(ctx context.Context, method, endpoint string, body io.Reader, o *requestOptions) (*http.Request, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.url(endpoint), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	for key, values := range c.header {
+		req.Header[key] = values
+	}
+	token := c.Token
+	if o.admin {
+		token = c.AdminToken
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	for key, values := range o.header {
+		req.Header[key] = values
+	}
+
+	return req, nil
+}
 
-	if err := checkHTTPStatus(resp.StatusCode, respBody); err != nil {
+// doStream performs a request without buffering the response body, for
+// downloads and other large payloads. The client-wide timeout does not apply
+// (use the context or WithTimeout instead). The caller must close the body.
+func (c *Client) doStream(ctx context.Context, method, endpoint string, body io.Reader, contentType string, opts ...RequestOption) (resp *http.Response, err error) {
+	o := newRequestOptions(opts)
+	if o.err != nil {
+		return nil, o.err
+	}
+	o.setRequestID(ctx)
+	endpoint = withQuery(endpoint, o.query)
+
+	if c.logger != nil {
+		start := c.now()
+		defer func() {
+			status := 0
+			if resp != nil {
+				status = resp.StatusCode
+			}
+			var apiErr *APIError
+			if errors.As(err, &apiErr) {
+				status = apiErr.Status
+			}
+			c.logRequest(ctx, method, endpoint, o.requestID(), status, nil, nil, err, c.now().Sub(start))
+		}()
+	}
+
+	if c.cache != nil && method != "GET" {
+		defer c.invalidateAfterWrite(endpoint)
+	}
+
+	if c.breaker != nil {
+		if err := c.breaker.allow(c.now()); err != nil {
+			return nil, err
+		}
+		defer func() {
+			c.breaker.record(c.now(), err)
+		}()
+	}
+
+	req, err := c.newRequest(ctx, method, endpoint, body, o)
+	if err != nil {
 		return nil, err
 	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	hc := *c.HTTPClient
+	hc.Timeout = o.timeout
+	resp, err = hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request %s failed: %w", o.requestID(), err)
+	}
+	decoded, err := decodedBody(resp)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	resp.Body = decoded
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return nil, checkHTTPStatus(resp.StatusCode, respBody, o.requestID())
+	}
 
-	return respBody, nil
+	return resp, nil
 }
 
 // New function to check HTTP status
-func checkHTTPStatus(statusCode int, respBody []byte) error {
+func checkHTTPStatus(statusCode int, respBody []byte, requestID string) error {
 	if statusCode >= 400 {
-		return fmt.Errorf("HTTP %d: %s", statusCode, respBody)
+		apiErr := newAPIError(statusCode, respBody)
+		apiErr.RequestID = requestID
+		return apiErr
 	}
 	return nil
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -25,6 +26,7 @@ type IncludeExclude struct {
 
 func main() {
 
+	ctx := context.Background()
 	client := gopocketbaseclient.NewClient("https://xxx.pockethost.io", "your_jwt_token")
 
 	// Create a new record
@@ -33,7 +35,7 @@ func main() {
 		"item2": "2",
 	}
 
-	client.CreateRecord("traffic_optimizer", row)
+	client.CreateRecord(ctx, "traffic_optimizer", row)
 
 	// Get record/s with filter
 	filters := map[string]string{
@@ -43,7 +45,7 @@ func main() {
 	}
 
 	// Fetch records with the specified filters
-	records, err := client.GetRecords("users", filters)
+	records, err := client.GetRecords(ctx, "users", filters)
 	if err != nil {
 		fmt.Println("Error fetching records:", err)
 		return
@@ -52,7 +54,7 @@ func main() {
 	fmt.Printf("Fetched Record: %v\n", records)
 
 	// All records
-	data, err := gopocketbaseclient.All(client, "traffic_optimizer")
+	data, err := gopocketbaseclient.All(ctx, client, "traffic_optimizer")
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,44 @@
+package gopocketbaseclient
+
+import "encoding/json"
+
+// Codec is the JSON implementation used for request bodies and record
+// decoding. Any library with encoding/json compatible behavior (sonic,
+// go-json, jsoniter, ...) can be plugged in with WithCodec.
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// StdCodec is the default Codec, backed by encoding/json.
+type StdCodec struct{}
+
+func (StdCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (StdCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// DecodeWith makes UnmarshalPocketBaseJSON decode with codec. UseNumber and
+// Lenient decoding keep using encoding/json.
+func DecodeWith(codec Codec) DecodeOption {
+	return func(o *decodeOptions) {
+		o.codec = codec
+	}
+}
+
+// EncodeWith makes MarshalPocketBaseJSON encode with codec.
+func EncodeWith(codec Codec) EncodeOption {
+	return func(o *encodeOptions) {
+		o.codec = codec
+	}
+}
+
+func (c *Client) marshal(v interface{}) ([]byte, error) {
+	if c.codec == nil {
+		return json.Marshal(v)
+	}
+	return c.codec.Marshal(v)
+}
@@ -0,0 +1,148 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"fmt"
+)
+
+// RecordService is a typed view of a single collection. Records are decoded
+// directly into T using UnmarshalPocketBaseJSON.
+type RecordService[T any] struct {
+	client *Client
+	name   string
+	view   bool
+}
+
+// ListResult is a typed page of records.
+type ListResult[T any] struct {
+	Page       int `json:"page"`
+	PerPage    int `json:"perPage"`
+	TotalItems int `json:"totalItems"`
+	TotalPages int `json:"totalPages"`
+	Items      []T `json:"items"`
+}
+
+// Collection returns a typed RecordService for the named collection, e.g.
+// Collection[Task](client, "tasks").
+func Collection[T any](client *Client, name string) *RecordService[T] {
+	return &RecordService[T]{client: client, name: name}
+}
+
+// View returns a typed RecordService for a view collection. Reads work as
+// for Collection; Create, Update and Delete fail with ErrReadOnly without a
+// request. View rows usually lack created and updated, which then stay
+// empty in an embedded BaseRecord.
+func View[T any](client *Client, name string) *RecordService[T] {
+	return &RecordService[T]{client: client, name: name, view: true}
+}
+
+// IsView reports whether the service was created with View.
+func (s *RecordService[T]) IsView() bool {
+	return s.view
+}
+
+// Name returns the collection name.
+func (s *RecordService[T]) Name() string {
+	return s.name
+}
+
+func (s *RecordService[T]) endpoint() string {
+	return "/api/collections/" + s.name + "/records"
+}
+
+func (s *RecordService[T]) GetOne(ctx context.Context, id string, opts ...RequestOption) (*T, error) {
+	respBody, err := s.client.doRequest(ctx, "GET", s.endpoint()+"/"+id, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.decodeOne(respBody)
+}
+
+func (s *RecordService[T]) GetList(ctx context.Context, page, perPage int, opts ...RequestOption) (*ListResult[T], error) {
+	list, err := s.client.GetList(ctx, s.name, page, perPage, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListResult[T]{
+		Page:       list.Page,
+		PerPage:    list.PerPage,
+		TotalItems: list.TotalItems,
+		TotalPages: list.TotalPages,
+	}
+	if err := s.client.decode(list.Items, &result.Items); err != nil {
+		return nil, fmt.Errorf("failed to decode %s records: %w", s.name, err)
+	}
+	return result, nil
+}
+
+// Create stores item. Struct items are encoded like in
+// Client.CreateRecordAndReturn, so an embedded BaseRecord is never sent.
+func (s *RecordService[T]) Create(ctx context.Context, item T, opts ...RequestOption) (*T, error) {
+	if s.view {
+		return nil, s.readOnly("create")
+	}
+	body, err := s.client.recordBody(item, true)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := s.client.doRequest(ctx, "POST", s.endpoint(), body, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create record: %w", err)
+	}
+	return s.decodeOne(respBody)
+}
+
+// Update patches the record with the fields of item, encoded like in Create.
+func (s *RecordService[T]) Update(ctx context.Context, id string, item T, opts ...RequestOption) (*T, error) {
+	if s.view {
+		return nil, s.readOnly("update")
+	}
+	body, err := s.client.recordBody(item, false)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := s.client.doRequest(ctx, "PATCH", s.endpoint()+"/"+id, body, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.decodeOne(respBody)
+}
+
+func (s *RecordService[T]) Delete(ctx context.Context, id string, opts ...RequestOption) error {
+	if s.view {
+		return s.readOnly("delete")
+	}
+	_, err := s.client.doRequest(ctx, "DELETE", s.endpoint()+"/"+id, nil, opts...)
+	return err
+}
+
+// GetFirst returns the first record matching filter in the given sort order,
+// like Client.GetFirst.
+func (s *RecordService[T]) GetFirst(ctx context.Context, filter, sortBy string, opts ...RequestOption) (*T, error) {
+	opts = append(opts[:len(opts):len(opts)], WithFilter(filter), WithSkipTotal())
+	if sortBy != "" {
+		opts = append(opts, WithSort(sortBy))
+	}
+
+	list, err := s.GetList(ctx, 1, 1, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Items) == 0 {
+		return nil, fmt.Errorf("no %s record matches %q: %w", s.name, filter, ErrNotFound)
+	}
+	return &list.Items[0], nil
+}
+
+func (s *RecordService[T]) readOnly(op string) error {
+	return fmt.Errorf("cannot %s %s records: %w", op, s.name, ErrReadOnly)
+}
+
+func (s *RecordService[T]) decodeOne(respBody []byte) (*T, error) {
+	var item T
+	if err := s.client.decode(respBody, &item); err != nil {
+		return nil, fmt.Errorf("failed to decode %s record: %w", s.name, err)
+	}
+	return &item, nil
+}
@@ -0,0 +1,202 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// CollectionModel describes a PocketBase collection. PocketBase 0.23+ lists
+// the columns in Fields; older versions use Schema.
+//
+// Rules are pointers: nil means superusers only and "" means public. They
+// are always sent, nil as null, so a model built from scratch locks every
+// rule it leaves unset; see UpdateCollection.
+type CollectionModel struct {
+	ID         string                 `json:"id,omitempty"`
+	Name       string                 `json:"name"`
+	Type       string                 `json:"type"`
+	System     bool                   `json:"system,omitempty"`
+	Fields     []SchemaField          `json:"fields,omitempty"`
+	Schema     []SchemaField          `json:"schema,omitempty"`
+	Indexes    []string               `json:"indexes,omitempty"`
+	ListRule   *string                `json:"listRule"`
+	ViewRule   *string                `json:"viewRule"`
+	CreateRule *string                `json:"createRule"`
+	UpdateRule *string                `json:"updateRule"`
+	DeleteRule *string                `json:"deleteRule"`
+	ViewQuery  string                 `json:"viewQuery,omitempty"`
+	Options    map[string]interface{} `json:"options,omitempty"`
+	Created    string                 `json:"created,omitempty"`
+	Updated    string                 `json:"updated,omitempty"`
+}
+
+// SchemaFields returns the collection's fields regardless of server version.
+func (m *CollectionModel) SchemaFields() []SchemaField {
+	if len(m.Fields) > 0 {
+		return m.Fields
+	}
+	return m.Schema
+}
+
+// SchemaField describes a single collection field. Type specific settings
+// (min, max, maxSelect, collectionId, values, ...) are kept in Extra on
+// PocketBase 0.23+, where they are top-level properties, and in Options on
+// older versions.
+type SchemaField struct {
+	ID          string                 `json:"id,omitempty"`
+	Name        string                 `json:"name"`
+	Type        string                 `json:"type"`
+	System      bool                   `json:"system,omitempty"`
+	Required    bool                   `json:"required,omitempty"`
+	Hidden      bool                   `json:"hidden,omitempty"`
+	Presentable bool                   `json:"presentable,omitempty"`
+	Options     map[string]interface{} `json:"options,omitempty"`
+	Extra       map[string]interface{} `json:"-"`
+}
+
+var schemaFieldKeys = map[string]bool{
+	"id": true, "name": true, "type": true, "system": true, "required": true,
+	"hidden": true, "presentable": true, "options": true,
+}
+
+func (f SchemaField) MarshalJSON() ([]byte, error) {
+	type alias SchemaField
+	data, err := json.Marshal(alias(f))
+	if err != nil || len(f.Extra) == 0 {
+		return data, err
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for k, v := range f.Extra {
+		if !schemaFieldKeys[k] {
+			m[k] = v
+		}
+	}
+	return json.Marshal(m)
+}
+
+func (f *SchemaField) UnmarshalJSON(data []byte) error {
+	type alias SchemaField
+	var a alias
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	for k, v := range m {
+		if schemaFieldKeys[k] {
+			continue
+		}
+		if a.Extra == nil {
+			a.Extra = make(map[string]interface{})
+		}
+		a.Extra[k] = v
+	}
+
+	*f = SchemaField(a)
+	return nil
+}
+
+// Option returns a type specific setting from Extra or, on older servers,
+// from Options.
+func (f *SchemaField) Option(name string) (interface{}, bool) {
+	if v, ok := f.Extra[name]; ok {
+		return v, true
+	}
+	v, ok := f.Options[name]
+	return v, ok
+}
+
+// ListCollections returns every collection. Managing collections requires
+// superuser credentials, e.g. by passing WithAdminAuth().
+func (c *Client) ListCollections(ctx context.Context, opts ...RequestOption) ([]CollectionModel, error) {
+	var collections []CollectionModel
+	for page := 1; ; page++ {
+		pageOpts := append(opts[:len(opts):len(opts)], WithPage(page), WithPerPage(DefaultBatchSize))
+		respBody, err := c.doRequest(ctx, "GET", "/api/collections", nil, pageOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list collections: %w", err)
+		}
+
+		var list struct {
+			TotalPages int               `json:"totalPages"`
+			Items      []CollectionModel `json:"items"`
+		}
+		err = json.Unmarshal(respBody, &list)
+		if err != nil {
+			return nil, err
+		}
+		collections = append(collections, list.Items...)
+
+		if len(list.Items) < DefaultBatchSize || page >= list.TotalPages {
+			return collections, nil
+		}
+	}
+}
+
+func (c *Client) GetCollection(ctx context.Context, idOrName string, opts ...RequestOption) (*CollectionModel, error) {
+	respBody, err := c.doRequest(ctx, "GET", "/api/collections/"+idOrName, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection %s: %w", idOrName, err)
+	}
+	return decodeCollection(respBody)
+}
+
+func (c *Client) CreateCollection(ctx context.Context, collection CollectionModel, opts ...RequestOption) (*CollectionModel, error) {
+	respBody, err := c.doRequest(ctx, "POST", "/api/collections", collection, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create collection %s: %w", collection.Name, err)
+	}
+	return decodeCollection(respBody)
+}
+
+// UpdateCollection replaces the collection's settings with collection. Nil
+// rules are sent as null and restrict the action to superusers, so change an
+// existing collection by editing the model returned by GetCollection rather
+// than a partial one.
+func (c *Client) UpdateCollection(ctx context.Context, idOrName string, collection CollectionModel, opts ...RequestOption) (*CollectionModel, error) {
+	respBody, err := c.doRequest(ctx, "PATCH", "/api/collections/"+idOrName, collection, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update collection %s: %w", idOrName, err)
+	}
+	return decodeCollection(respBody)
+}
+
+func (c *Client) DeleteCollection(ctx context.Context, idOrName string, opts ...RequestOption) error {
+	_, err := c.doRequest(ctx, "DELETE", "/api/collections/"+idOrName, nil, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to delete collection %s: %w", idOrName, err)
+	}
+	return nil
+}
+
+func decodeCollection(respBody []byte) (*CollectionModel, error) {
+	var collection CollectionModel
+	err := json.Unmarshal(respBody, &collection)
+	if err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// ImportCollections syncs a full schema definition in one call. With
+// deleteMissing, collections and fields not present in collections are
+// deleted from the instance.
+func (c *Client) ImportCollections(ctx context.Context, collections []CollectionModel, deleteMissing bool, opts ...RequestOption) error {
+	payload := map[string]interface{}{
+		"collections":   collections,
+		"deleteMissing": deleteMissing,
+	}
+	_, err := c.doRequest(ctx, "PUT", "/api/collections/import", payload, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to import collections: %w", err)
+	}
+	return nil
+}
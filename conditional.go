@@ -0,0 +1,92 @@
+package gopocketbaseclient
+
+import (
+	"bytes"
+	"container/list"
+	"net/http"
+	"sync"
+)
+
+// WithConditionalRequests remembers the ETag and Last-Modified headers that
+// a reverse proxy in front of PocketBase returns for GET requests, and sends
+// If-None-Match / If-Modified-Since when the same URL is requested again with
+// the same token. A 304 Not Modified answer is served from the remembered
+// body, saving the transfer of large, rarely changing responses. At most
+// maxEntries responses are kept, least recently used first out.
+func WithConditionalRequests(maxEntries int) ClientOption {
+	return func(c *Client) {
+		if maxEntries <= 0 {
+			maxEntries = DefaultResponseCacheEntries
+		}
+		c.validators = &validatorCache{
+			max:     maxEntries,
+			entries: make(map[string]*list.Element),
+			lru:     list.New(),
+		}
+	}
+}
+
+type validatorCache struct {
+	max int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type validatedResponse struct {
+	key          string
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+func (vc *validatorCache) get(key string) (*validatedResponse, bool) {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	el, ok := vc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	vc.lru.MoveToFront(el)
+	return el.Value.(*validatedResponse), true
+}
+
+// store remembers body if the response carries validators, and forgets the
+// previous response otherwise.
+func (vc *validatorCache) store(key string, header http.Header, body []byte) {
+	entry := &validatedResponse{
+		key:          key,
+		etag:         header.Get("ETag"),
+		lastModified: header.Get("Last-Modified"),
+		body:         bytes.Clone(body),
+	}
+
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	if el, ok := vc.entries[key]; ok {
+		vc.lru.Remove(el)
+		delete(vc.entries, key)
+	}
+	if entry.etag == "" && entry.lastModified == "" {
+		return
+	}
+	vc.entries[key] = vc.lru.PushFront(entry)
+	for vc.lru.Len() > vc.max {
+		oldest := vc.lru.Back()
+		vc.lru.Remove(oldest)
+		delete(vc.entries, oldest.Value.(*validatedResponse).key)
+	}
+}
+
+// setConditional adds the conditional headers for a remembered response.
+func (e *validatedResponse) setConditional(req *http.Request) {
+	if e.etag != "" {
+		req.Header.Set("If-None-Match", e.etag)
+	}
+	if e.lastModified != "" {
+		req.Header.Set("If-Modified-Since", e.lastModified)
+	}
+}
@@ -0,0 +1,39 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// CronJob is a scheduled job registered in PocketBase (0.23+).
+type CronJob struct {
+	ID         string `json:"id"`
+	Expression string `json:"expression"`
+}
+
+func (c *Client) ListCrons(ctx context.Context, opts ...RequestOption) ([]CronJob, error) {
+	respBody, err := c.doRequest(ctx, "GET", "/api/crons", nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list crons: %w", err)
+	}
+
+	var jobs []CronJob
+	err = json.Unmarshal(respBody, &jobs)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// RunCron triggers a cron job immediately, e.g. "__pbDBOptimize__" or the
+// backups job.
+func (c *Client) RunCron(ctx context.Context, jobID string, opts ...RequestOption) error {
+	_, err := c.doRequest(ctx, "POST", "/api/crons/"+url.PathEscape(jobID), nil, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to run cron %s: %w", jobID, err)
+	}
+	return nil
+}
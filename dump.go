@@ -0,0 +1,75 @@
+package gopocketbaseclient
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// RequestDump captures an outgoing request as a copy-pasteable curl command
+// together with the raw response, for reproducing API issues outside Go.
+type RequestDump struct {
+	Curl         string
+	Status       int
+	ResponseBody []byte
+	Err          error
+}
+
+// WithDump calls fn with a dump of this request once it completes.
+func WithDump(fn func(RequestDump)) RequestOption {
+	return func(o *requestOptions) {
+		o.dump = fn
+	}
+}
+
+func curlCommand(req *http.Request, body []byte) string {
+	var b strings.Builder
+	b.WriteString("curl -X ")
+	b.WriteString(req.Method)
+	b.WriteString(" ")
+	b.WriteString(shellQuote(req.URL.String()))
+
+	keys := make([]string, 0, len(req.Header))
+	for key := range req.Header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, value := range req.Header[key] {
+			if strings.EqualFold(key, "Authorization") {
+				value = maskAuthorization(value)
+			}
+			b.WriteString(" -H ")
+			b.WriteString(shellQuote(key + ": " + value))
+		}
+	}
+
+	if len(body) > 0 {
+		b.WriteString(" --data-raw ")
+		b.WriteString(shellQuote(redactBody(body)))
+	}
+	return b.String()
+}
+
+func maskAuthorization(value string) string {
+	scheme, token, found := strings.Cut(value, " ")
+	if !found {
+		return redacted
+	}
+	if len(token) > 8 {
+		return scheme + " " + token[:4] + "..." + redacted
+	}
+	return scheme + " " + redacted
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func (c *Client) dumpFunc(o *requestOptions) func(RequestDump) {
+	if o.dump != nil {
+		return o.dump
+	}
+	return c.dump
+}
@@ -0,0 +1,124 @@
+package gopocketbaseclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound     = errors.New("pocketbase: not found")
+	ErrUnauthorized = errors.New("pocketbase: unauthorized")
+	ErrForbidden    = errors.New("pocketbase: forbidden")
+	ErrRateLimited  = errors.New("pocketbase: rate limited")
+	// ErrConflict is returned by UpdateIfUnchanged when the record changed
+	// since it was read.
+	ErrConflict = errors.New("pocketbase: record changed since it was read")
+	// ErrReadOnly is returned when writing to a view collection.
+	ErrReadOnly = errors.New("pocketbase: view collections are read-only")
+	// ErrInvalidBaseURL is returned by every request of a client created with
+	// a malformed base URL.
+	ErrInvalidBaseURL = errors.New("pocketbase: invalid base URL")
+	// ErrCircuitOpen is returned without contacting the server while the
+	// circuit breaker is open.
+	ErrCircuitOpen = errors.New("pocketbase: circuit breaker open")
+	// ErrNoRecords is returned by GetRecords when nothing matches, unless
+	// WithAllowEmpty is set.
+	ErrNoRecords = errors.New("no records found")
+)
+
+// ValidationError describes why PocketBase rejected a single field.
+type ValidationError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// APIError is returned for every PocketBase response with a 4xx/5xx status.
+// It matches ErrNotFound, ErrUnauthorized, ErrForbidden and ErrRateLimited
+// with errors.Is.
+type APIError struct {
+	Status  int
+	Code    int
+	Message string
+	Data    map[string]ValidationError
+	Body    []byte
+	// RequestID is the X-Request-ID the request was sent with.
+	RequestID string
+	// RetryAfter is the delay requested by the response's Retry-After
+	// header, or 0.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("HTTP %d: %s", e.Status, e.Message)
+	if len(e.Data) > 0 {
+		msg += " (" + e.fieldErrors() + ")"
+	}
+	if e.RequestID != "" {
+		msg += " [request " + e.RequestID + "]"
+	}
+	return msg
+}
+
+func (e *APIError) fieldErrors() string {
+	fields := make([]string, 0, len(e.Data))
+	for field := range e.Data {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	details := make([]string, len(fields))
+	for i, field := range fields {
+		details[i] = fmt.Sprintf("%s: %s", field, e.Data[field].Message)
+	}
+	return strings.Join(details, "; ")
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Status == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.Status == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.Status == http.StatusForbidden
+	case ErrRateLimited:
+		return e.Status == http.StatusTooManyRequests
+	}
+	return false
+}
+
+func newAPIError(statusCode int, respBody []byte) *APIError {
+	apiErr := &APIError{
+		Status:  statusCode,
+		Message: string(respBody),
+		Body:    respBody,
+	}
+
+	var payload struct {
+		Code    int                        `json:"code"`
+		Message string                     `json:"message"`
+		Data    map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &payload); err != nil || payload.Message == "" {
+		return apiErr
+	}
+
+	apiErr.Code = payload.Code
+	apiErr.Message = payload.Message
+	for field, raw := range payload.Data {
+		var v ValidationError
+		if err := json.Unmarshal(raw, &v); err != nil || v.Code == "" {
+			continue
+		}
+		if apiErr.Data == nil {
+			apiErr.Data = make(map[string]ValidationError)
+		}
+		apiErr.Data[field] = v
+	}
+	return apiErr
+}
@@ -0,0 +1,193 @@
+package gopocketbaseclient
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Expanded relations are decoded into struct fields tagged with the relation
+// they hold, next to the field holding the ID:
+//
+//	type Task struct {
+//		BaseRecord
+//		ProjectID string   `json:"project_id"`
+//		Project   *Project `json:"-" pb:"expand=project_id"`
+//	}
+//
+// Fetch with WithExpand("project_id") and Project is filled from the record's
+// expand object; it stays nil when the relation was not expanded. Use a slice
+// for multi-relations. The json:"-" tag keeps the field out of request bodies.
+
+var expandTypes sync.Map // reflect.Type -> bool
+
+// MaxExpandDepth is the number of relation levels PocketBase expands in one
+// path.
+const MaxExpandDepth = 6
+
+// ValidateExpand checks a comma-separated expand parameter such as
+// "project_id.owner_id,tasks_via_project" before it is sent.
+func ValidateExpand(expand string) error {
+	for _, path := range strings.Split(expand, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			return fmt.Errorf("invalid expand %q: empty relation", expand)
+		}
+
+		if strings.ContainsAny(path, "()") {
+			return fmt.Errorf("invalid expand %q: back-relations are written <collection>_via_<field>, e.g. \"tasks_via_project\"", path)
+		}
+
+		parts := strings.Split(path, ".")
+		if len(parts) > MaxExpandDepth {
+			return fmt.Errorf("invalid expand %q: %d levels, PocketBase expands at most %d", path, len(parts), MaxExpandDepth)
+		}
+		for _, part := range parts {
+			if !isIdentifier(part) {
+				return fmt.Errorf("invalid expand %q: %q is not a field name", path, part)
+			}
+			if before, after, ok := strings.Cut(part, "_via_"); ok && (before == "" || after == "") {
+				return fmt.Errorf("invalid expand %q: back-relation %q needs both a collection and a field", path, part)
+			}
+		}
+	}
+	return nil
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r != '_' && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// expandTag returns the relation named by a pb:"expand=..." tag.
+func expandTag(field reflect.StructField) (string, bool) {
+	for _, part := range strings.Split(field.Tag.Get("pb"), ",") {
+		if rel, ok := strings.CutPrefix(part, "expand="); ok && rel != "" {
+			return rel, true
+		}
+	}
+	return "", false
+}
+
+// hasExpandFields reports whether values of t can contain expand-tagged
+// fields, so decoding can skip the walk for everything else.
+func hasExpandFields(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+	if v, ok := expandTypes.Load(t); ok {
+		return v.(bool)
+	}
+	found := scanExpandFields(t, make(map[reflect.Type]bool))
+	expandTypes.Store(t, found)
+	return found
+}
+
+func scanExpandFields(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return scanExpandFields(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if _, ok := expandTag(field); ok {
+				return true
+			}
+			if scanExpandFields(field.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fillExpands walks v alongside the generic JSON it was decoded from and
+// decodes expanded relations into the fields tagged for them.
+func fillExpands(raw interface{}, v reflect.Value, o decodeOptions) error {
+	if !v.IsValid() || !hasExpandFields(v.Type()) {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return fillExpands(raw, v.Elem(), o)
+	case reflect.Slice, reflect.Array:
+		items, ok := raw.([]interface{})
+		if !ok {
+			return nil
+		}
+		for i := 0; i < v.Len() && i < len(items); i++ {
+			if err := fillExpands(items[i], v.Index(i), o); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		return fillStructExpands(m, v, o)
+	}
+	return nil
+}
+
+func fillStructExpands(m map[string]interface{}, v reflect.Value, o decodeOptions) error {
+	expand, _ := m["expand"].(map[string]interface{})
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if rel, ok := expandTag(field); ok {
+			value, found := expand[rel]
+			if !found || value == nil || !v.Field(i).CanAddr() {
+				continue
+			}
+			if err := decodeRaw(value, v.Field(i).Addr().Interface(), o); err != nil {
+				return fmt.Errorf("failed to decode expand %s: %w", rel, err)
+			}
+			continue
+		}
+
+		name, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			if err := fillExpands(m, v.Field(i), o); err != nil {
+				return err
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if value, found := lookupField(m, name); found {
+			if err := fillExpands(value, v.Field(i), o); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
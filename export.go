@@ -0,0 +1,134 @@
+package gopocketbaseclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExportFormat selects the output of ExportCollection.
+type ExportFormat string
+
+const (
+	// ExportJSONL writes one JSON object per line.
+	ExportJSONL ExportFormat = "jsonl"
+	// ExportCSV writes a header row followed by one row per record. Arrays
+	// and objects are written as JSON.
+	ExportCSV ExportFormat = "csv"
+)
+
+// ExportCollection streams every record matching opts into w, page by page.
+// Pass WithFields to select (and, for CSV, order) the exported columns;
+// otherwise CSV columns are the sorted fields of the first record.
+func (c *Client) ExportCollection(ctx context.Context, collection string, w io.Writer, format ExportFormat, opts ...RequestOption) error {
+	var write func(json.RawMessage) error
+	var flush func() error
+	switch format {
+	case ExportJSONL:
+		write = func(item json.RawMessage) error {
+			var buf bytes.Buffer
+			if err := json.Compact(&buf, item); err != nil {
+				return err
+			}
+			buf.WriteByte('\n')
+			_, err := w.Write(buf.Bytes())
+			return err
+		}
+		flush = func() error { return nil }
+	case ExportCSV:
+		cw := csv.NewWriter(w)
+		columns := exportColumns(opts)
+		write = func(item json.RawMessage) error {
+			var record map[string]interface{}
+			decoder := json.NewDecoder(bytes.NewReader(item))
+			decoder.UseNumber()
+			if err := decoder.Decode(&record); err != nil {
+				return err
+			}
+			if columns == nil {
+				for name := range record {
+					columns = append(columns, name)
+				}
+				sort.Strings(columns)
+				if err := cw.Write(columns); err != nil {
+					return err
+				}
+			}
+
+			row := make([]string, len(columns))
+			for i, name := range columns {
+				value, err := csvValue(record[name])
+				if err != nil {
+					return fmt.Errorf("field %s: %w", name, err)
+				}
+				row[i] = value
+			}
+			return cw.Write(row)
+		}
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+		if columns != nil {
+			if err := cw.Write(columns); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+
+	var exportErr error
+	c.Iterate(ctx, collection, opts...)(func(item json.RawMessage, err error) bool {
+		if err == nil {
+			err = write(item)
+		}
+		exportErr = err
+		return err == nil
+	})
+	if exportErr != nil {
+		return fmt.Errorf("failed to export %s: %w", collection, exportErr)
+	}
+	return flush()
+}
+
+// exportColumns returns the plain field names requested with WithFields, or
+// nil when there are none or they use wildcards or modifiers.
+func exportColumns(opts []RequestOption) []string {
+	fields := newRequestOptions(opts).query.Get("fields")
+	if fields == "" {
+		return nil
+	}
+
+	columns := strings.Split(fields, ",")
+	for i, column := range columns {
+		column = strings.TrimSpace(column)
+		if column == "" || strings.ContainsAny(column, "*.:") {
+			return nil
+		}
+		columns[i] = column
+	}
+	return columns
+}
+
+func csvValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		data, err := json.Marshal(v)
+		return string(data), err
+	}
+}
@@ -0,0 +1,130 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ExternalAuth is an OAuth2 identity (Google, GitHub, ...) linked to a record.
+type ExternalAuth struct {
+	ID            string `json:"id"`
+	CollectionRef string `json:"collectionRef"`
+	RecordRef     string `json:"recordRef"`
+	Provider      string `json:"provider"`
+	ProviderID    string `json:"providerId"`
+	Created       string `json:"created"`
+	Updated       string `json:"updated"`
+}
+
+// UnmarshalJSON also accepts the field names used before PocketBase 0.23.
+func (e *ExternalAuth) UnmarshalJSON(data []byte) error {
+	type alias ExternalAuth
+	var v struct {
+		alias
+		CollectionID string `json:"collectionId"`
+		RecordID     string `json:"recordId"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*e = ExternalAuth(v.alias)
+	if e.CollectionRef == "" {
+		e.CollectionRef = v.CollectionID
+	}
+	if e.RecordRef == "" {
+		e.RecordRef = v.RecordID
+	}
+	return nil
+}
+
+// ListExternalAuths returns the OAuth2 identities linked to a user. It uses
+// the _externalAuths collection (PocketBase 0.23+) and falls back to the
+// legacy external-auths endpoint on older servers.
+func (c *Client) ListExternalAuths(ctx context.Context, collection, userID string) ([]ExternalAuth, error) {
+	auths, _, err := c.listExternalAuths(ctx, collection, userID)
+	return auths, err
+}
+
+// UnlinkExternalAuth removes the link between a user and an OAuth2 provider.
+func (c *Client) UnlinkExternalAuth(ctx context.Context, collection, userID, provider string) error {
+	auths, legacy, err := c.listExternalAuths(ctx, collection, userID)
+	if err != nil {
+		return err
+	}
+
+	if legacy {
+		endpoint := "/api/collections/" + collection + "/records/" + userID + "/external-auths/" + provider
+		_, err = c.doRequest(ctx, "DELETE", endpoint, nil)
+		return err
+	}
+
+	for _, auth := range auths {
+		if auth.Provider == provider {
+			return c.DeleteRecord(ctx, "_externalAuths", auth.ID)
+		}
+	}
+	return fmt.Errorf("no %s identity linked to %s: %w", provider, userID, ErrNotFound)
+}
+
+func (c *Client) listExternalAuths(ctx context.Context, collection, userID string) ([]ExternalAuth, bool, error) {
+	list, err := c.GetFullList(ctx, "_externalAuths", 0, WithFilter(Eq("recordRef", userID).String()))
+	if err == nil {
+		var auths []ExternalAuth
+		err = json.Unmarshal(list.Items, &auths)
+		if err != nil {
+			return nil, false, err
+		}
+		auths, err = c.filterCollectionRef(ctx, collection, userID, auths)
+		return auths, false, err
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, false, fmt.Errorf("failed to list external auths: %w", err)
+	}
+
+	endpoint := "/api/collections/" + collection + "/records/" + userID + "/external-auths"
+	respBody, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to list external auths: %w", err)
+	}
+
+	var auths []ExternalAuth
+	err = json.Unmarshal(respBody, &auths)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return auths, true, nil
+}
+
+// filterCollectionRef keeps the identities of userID in collection: record
+// IDs are only unique per collection, so other auth collections can hold a
+// record with the same ID. collectionRef is the collection ID, which is
+// looked up on the user record when collection is given by name.
+func (c *Client) filterCollectionRef(ctx context.Context, collection, userID string, auths []ExternalAuth) ([]ExternalAuth, error) {
+	collectionID := collection
+	for _, auth := range auths {
+		if auth.CollectionRef == collectionID {
+			continue
+		}
+		record, err := c.GetRecord(ctx, collection, userID, WithFields("collectionId"))
+		if errors.Is(err, ErrNotFound) {
+			return []ExternalAuth{}, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list external auths: %w", err)
+		}
+		collectionID, _ = record["collectionId"].(string)
+		break
+	}
+
+	filtered := auths[:0]
+	for _, auth := range auths {
+		if auth.CollectionRef == collectionID {
+			filtered = append(filtered, auth)
+		}
+	}
+	return filtered, nil
+}
@@ -0,0 +1,118 @@
+package gopocketbaseclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// MultiSelect holds the values of a select field. It decodes both the string
+// PocketBase returns for single selects and the array returned for multiple
+// selects, and always encodes as an array.
+type MultiSelect []string
+
+// Contains reports whether value is selected.
+func (m MultiSelect) Contains(value string) bool {
+	for _, v := range m {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (m MultiSelect) MarshalJSON() ([]byte, error) {
+	return marshalStringList(m)
+}
+
+func (m *MultiSelect) UnmarshalJSON(data []byte) error {
+	values, err := unmarshalStringList(data)
+	if err != nil {
+		return fmt.Errorf("invalid select value: %w", err)
+	}
+	*m = values
+	return nil
+}
+
+// FileField holds the filenames of a file field, decoded from either a
+// single filename or a list. Build download URLs with the client's file
+// helpers; sending the list back keeps the files it names.
+type FileField []string
+
+func (f FileField) MarshalJSON() ([]byte, error) {
+	return marshalStringList(f)
+}
+
+func (f *FileField) UnmarshalJSON(data []byte) error {
+	values, err := unmarshalStringList(data)
+	if err != nil {
+		return fmt.Errorf("invalid file value: %w", err)
+	}
+	*f = values
+	return nil
+}
+
+// marshalStringList encodes nil as [] so that clearing a field is explicit.
+func marshalStringList(values []string) ([]byte, error) {
+	if values == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(values)
+}
+
+func unmarshalStringList(data []byte) ([]string, error) {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil, nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, err
+		}
+		if s == "" {
+			return nil, nil
+		}
+		return []string{s}, nil
+	}
+
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// JSONField holds the content of a json field decoded into T. Valid is false
+// when the column is null, which is also how an invalid field is encoded.
+type JSONField[T any] struct {
+	Value T
+	Valid bool
+}
+
+// NewJSONField returns a valid JSONField holding v.
+func NewJSONField[T any](v T) JSONField[T] {
+	return JSONField[T]{Value: v, Valid: true}
+}
+
+func (f JSONField[T]) MarshalJSON() ([]byte, error) {
+	if !f.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(f.Value)
+}
+
+func (f *JSONField[T]) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*f = JSONField[T]{}
+		return nil
+	}
+
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("invalid json field: %w", err)
+	}
+	*f = JSONField[T]{Value: v, Valid: true}
+	return nil
+}
@@ -0,0 +1,167 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+)
+
+// FileUpload is a file to attach to a record's file field.
+type FileUpload struct {
+	Filename string
+	Reader   io.Reader
+}
+
+// CreateRecordWithFiles creates a record using a multipart/form-data request
+// so that file fields can be populated. files maps a field name to the files
+// to upload into it; regular fields are sent alongside as JSON.
+func (c *Client) CreateRecordWithFiles(ctx context.Context, collection string, record map[string]interface{}, files map[string][]FileUpload, opts ...RequestOption) (map[string]interface{}, error) {
+	endpoint := "/api/collections/" + collection + "/records"
+	created, err := c.doMultipart(ctx, "POST", endpoint, record, files, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create record: %w", err)
+	}
+	return created, nil
+}
+
+// UpdateRecordWithFiles is the multipart counterpart of UpdateRecord. Uploaded
+// files replace the field's value unless the field name carries PocketBase's
+// "+" modifier; see AppendFilesModifier.
+func (c *Client) UpdateRecordWithFiles(ctx context.Context, collection, id string, record map[string]interface{}, files map[string][]FileUpload, opts ...RequestOption) (map[string]interface{}, error) {
+	endpoint := "/api/collections/" + collection + "/records/" + id
+	updated, err := c.doMultipart(ctx, "PATCH", endpoint, record, files, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update record: %w", err)
+	}
+	return updated, nil
+}
+
+func (c *Client) doMultipart(ctx context.Context, method, endpoint string, record map[string]interface{}, files map[string][]FileUpload, opts ...RequestOption) (map[string]interface{}, error) {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeMultipart(mw, payload, files))
+	}()
+
+	resp, err := c.doStream(ctx, method, endpoint, pr, mw.FormDataContentType(), opts...)
+	pr.Close()
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var result map[string]interface{}
+	err = c.decode(respBody, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func writeMultipart(mw *multipart.Writer, payload []byte, files map[string][]FileUpload) error {
+	if len(payload) > 0 && string(payload) != "null" {
+		if err := mw.WriteField("@jsonPayload", string(payload)); err != nil {
+			return err
+		}
+	}
+
+	for field, uploads := range files {
+		for _, upload := range uploads {
+			part, err := mw.CreateFormFile(field, upload.Filename)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(part, upload.Reader); err != nil {
+				return fmt.Errorf("failed to read %s: %w", upload.Filename, err)
+			}
+		}
+	}
+
+	return mw.Close()
+}
+
+// WithThumb requests a thumbnail of an image file, e.g. "100x100" or "0x300".
+func WithThumb(size string) RequestOption {
+	return WithQueryParam("thumb", size)
+}
+
+// WithFileToken makes DownloadFile fetch a short-lived file token first, which
+// is required for protected file fields.
+func WithFileToken() RequestOption {
+	return func(o *requestOptions) {
+		o.fileToken = true
+	}
+}
+
+// FileURL returns the URL of a record's file. It does not include a token.
+func (c *Client) FileURL(collection, recordID, filename string) string {
+	return c.url(fileEndpoint(collection, recordID, filename))
+}
+
+func fileEndpoint(collection, recordID, filename string) string {
+	return "/api/files/" + url.PathEscape(collection) + "/" + url.PathEscape(recordID) + "/" + url.PathEscape(filename)
+}
+
+// DownloadFile streams a record's file into w without buffering it in memory.
+func (c *Client) DownloadFile(ctx context.Context, collection, recordID, filename string, w io.Writer, opts ...RequestOption) error {
+	if newRequestOptions(opts).fileToken {
+		token, err := c.FileToken(ctx, opts...)
+		if err != nil {
+			return err
+		}
+		opts = append(opts[:len(opts):len(opts)], WithQueryParam("token", token))
+	}
+
+	resp, err := c.doStream(ctx, "GET", fileEndpoint(collection, recordID, filename), nil, "", opts...)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", filename, err)
+	}
+	defer resp.Body.Close()
+
+	_, err = io.Copy(w, resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", filename, err)
+	}
+	return nil
+}
+
+// RemoveFilesModifier returns the record change that deletes the named files
+// from a file field using PocketBase's "field-" modifier.
+func RemoveFilesModifier(field string, filenames ...string) map[string]interface{} {
+	return map[string]interface{}{field + "-": filenames}
+}
+
+// AppendFilesModifier returns the uploads that add files to a multi-file field
+// using PocketBase's "field+" modifier instead of replacing its value.
+func AppendFilesModifier(field string, files ...FileUpload) map[string][]FileUpload {
+	return map[string][]FileUpload{field + "+": files}
+}
+
+// AddFiles uploads files into a multi-file field, keeping the existing ones.
+func (c *Client) AddFiles(ctx context.Context, collection, id, field string, files []FileUpload, opts ...RequestOption) (map[string]interface{}, error) {
+	return c.UpdateRecordWithFiles(ctx, collection, id, nil, AppendFilesModifier(field, files...), opts...)
+}
+
+// DeleteFiles removes the named files from a file field.
+func (c *Client) DeleteFiles(ctx context.Context, collection, id, field string, filenames []string, opts ...RequestOption) error {
+	return c.UpdateRecord(ctx, collection, id, RemoveFilesModifier(field, filenames...), opts...)
+}
+
+// ReplaceFile swaps one file of a field for a new upload in a single request.
+func (c *Client) ReplaceFile(ctx context.Context, collection, id, field, oldFilename string, file FileUpload, opts ...RequestOption) (map[string]interface{}, error) {
+	return c.UpdateRecordWithFiles(ctx, collection, id, RemoveFilesModifier(field, oldFilename), AppendFilesModifier(field, file), opts...)
+}
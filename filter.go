@@ -0,0 +1,204 @@
+package gopocketbaseclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Filter is a composable PocketBase filter expression. Build one with Eq, Gt,
+// Like, In, ... and combine them with And, Or and Not; String renders the
+// PocketBase filter syntax with all values quoted and escaped. String values
+// cannot end in a backslash in that syntax, so trailing backslashes are
+// removed. The zero Filter matches every record.
+type Filter struct {
+	field    string
+	op       string
+	value    string
+	join     string
+	children []Filter
+}
+
+var negatedOperators = map[string]string{
+	"=":  "!=",
+	"!=": "=",
+	">":  "<=",
+	">=": "<",
+	"<":  ">=",
+	"<=": ">",
+	"~":  "!~",
+	"!~": "~",
+}
+
+func compare(field, op string, value interface{}) Filter {
+	return Filter{field: field, op: op, value: formatFilterValue(value)}
+}
+
+func Eq(field string, value interface{}) Filter  { return compare(field, "=", value) }
+func Neq(field string, value interface{}) Filter { return compare(field, "!=", value) }
+func Gt(field string, value interface{}) Filter  { return compare(field, ">", value) }
+func Gte(field string, value interface{}) Filter { return compare(field, ">=", value) }
+func Lt(field string, value interface{}) Filter  { return compare(field, "<", value) }
+func Lte(field string, value interface{}) Filter { return compare(field, "<=", value) }
+
+// Like matches field against a pattern; PocketBase wraps it in % wildcards
+// unless the pattern already contains one.
+func Like(field string, pattern string) Filter { return compare(field, "~", pattern) }
+
+// IsNull matches records where field is empty.
+func IsNull(field string) Filter { return compare(field, "=", nil) }
+
+// In matches records whose field equals any of values. With no values it
+// matches nothing.
+func In(field string, values ...interface{}) Filter {
+	if len(values) == 0 {
+		return And(IsNull(field), Not(IsNull(field)))
+	}
+	filters := make([]Filter, len(values))
+	for i, v := range values {
+		filters[i] = Eq(field, v)
+	}
+	return Or(filters...)
+}
+
+// And matches records satisfying every filter. Zero filters are ignored.
+func And(filters ...Filter) Filter { return group("&&", filters) }
+
+// Or matches records satisfying at least one filter. Zero filters are ignored.
+func Or(filters ...Filter) Filter { return group("||", filters) }
+
+// Not negates a filter. PocketBase has no negation operator, so comparisons
+// are inverted and groups rewritten using De Morgan's laws.
+func Not(f Filter) Filter {
+	if f.IsZero() {
+		return f
+	}
+	if f.join == "" {
+		f.op = negatedOperators[f.op]
+		return f
+	}
+
+	children := make([]Filter, len(f.children))
+	for i, child := range f.children {
+		children[i] = Not(child)
+	}
+	if f.join == "&&" {
+		return Or(children...)
+	}
+	return And(children...)
+}
+
+func group(join string, filters []Filter) Filter {
+	var children []Filter
+	for _, f := range filters {
+		if !f.IsZero() {
+			children = append(children, f)
+		}
+	}
+	switch len(children) {
+	case 0:
+		return Filter{}
+	case 1:
+		return children[0]
+	}
+	return Filter{join: join, children: children}
+}
+
+// And combines f with others using &&.
+func (f Filter) And(others ...Filter) Filter {
+	return And(append([]Filter{f}, others...)...)
+}
+
+// Or combines f with others using ||.
+func (f Filter) Or(others ...Filter) Filter {
+	return Or(append([]Filter{f}, others...)...)
+}
+
+// IsZero reports whether f is empty.
+func (f Filter) IsZero() bool {
+	return f.op == "" && f.join == ""
+}
+
+func (f Filter) String() string {
+	if f.join == "" {
+		if f.op == "" {
+			return ""
+		}
+		return f.field + " " + f.op + " " + f.value
+	}
+
+	parts := make([]string, len(f.children))
+	for i, child := range f.children {
+		if child.join != "" {
+			parts[i] = "(" + child.String() + ")"
+		} else {
+			parts[i] = child.String()
+		}
+	}
+	return strings.Join(parts, " "+f.join+" ")
+}
+
+func formatFilterValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return quoteFilterString(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	case time.Time:
+		return quoteFilterString(v.UTC().Format(PocketBaseTimeLayout))
+	case PocketBaseTime:
+		return quoteFilterString(v.UTC().Format(PocketBaseTimeLayout))
+	case fmt.Stringer:
+		return quoteFilterString(v.String())
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return quoteFilterString(fmt.Sprint(value))
+	}
+	return quoteFilterString(string(data))
+}
+
+// quoteFilterString quotes s as a single-quoted filter string. PocketBase
+// only unescapes \' and never ends a string at a quote that follows a
+// backslash, so other backslashes are kept as they are. A trailing
+// backslash would escape the closing quote and cannot be expressed; it is
+// removed.
+func quoteFilterString(s string) string {
+	return "'" + strings.ReplaceAll(strings.TrimRight(s, `\`), "'", `\'`) + "'"
+}
+
+// BuildFilter replaces {:name} placeholders in expr with the matching params,
+// quoted and escaped the same way as the filter builder, e.g.
+//
+//	BuildFilter("status = {:status} && due_date < {:date}", map[string]interface{}{
+//		"status": input,
+//		"date":   time.Now(),
+//	})
+//
+// Use it whenever user input is interpolated into a filter. Placeholders
+// without a matching param are left untouched. Trailing backslashes of
+// string values are removed; see Filter.
+func BuildFilter(expr string, params map[string]interface{}) string {
+	if len(params) == 0 {
+		return expr
+	}
+
+	replacements := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		replacements = append(replacements, "{:"+name+"}", formatFilterValue(value))
+	}
+	return strings.NewReplacer(replacements...).Replace(expr)
+}
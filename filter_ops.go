@@ -0,0 +1,88 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// UpdateByFilter applies changes to every record matching filter, e.g.
+//
+//	c.UpdateByFilter(ctx, "tasks", BuildFilter("due < {:now}", params),
+//		map[string]interface{}{"status": "archived"})
+//
+// The matching IDs are collected first, so records that stop matching
+// because of the update are still updated exactly once. The updates run
+// through the bulk machinery; Results are in ID order.
+func (c *Client) UpdateByFilter(ctx context.Context, collection, filter string, changes map[string]interface{}, opts ...RequestOption) (*BulkOperationResult, error) {
+	ids, err := c.matchingIDs(ctx, collection, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := "/api/collections/" + collection + "/records/"
+	result := newBulkResult(len(ids))
+	err = c.runBulk(ctx, "PATCH", result, opts, func(ctx context.Context, i int) (string, json.RawMessage, error) {
+		respBody, err := c.doRequest(ctx, "PATCH", endpoint+ids[i], changes, opts...)
+		if err != nil {
+			return ids[i], nil, fmt.Errorf("failed to update record: %w", err)
+		}
+		return ids[i], respBody, nil
+	})
+	return result, err
+}
+
+// WithDryRun makes DeleteByFilter only count the matching records.
+func WithDryRun() RequestOption {
+	return func(o *requestOptions) {
+		o.dryRun = true
+	}
+}
+
+// DeleteByFilter deletes every record matching filter through the bulk
+// machinery; use WithProgress to follow it. With WithDryRun nothing is
+// deleted: Results then has one entry, carrying the ID, per record that
+// would be deleted, and IDs stays empty.
+func (c *Client) DeleteByFilter(ctx context.Context, collection, filter string, opts ...RequestOption) (*BulkOperationResult, error) {
+	ids, err := c.matchingIDs(ctx, collection, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := newBulkResult(len(ids))
+	if newRequestOptions(opts).dryRun {
+		for i, id := range ids {
+			result.Results[i] = BulkItemResult{Index: i, ID: id}
+		}
+		return result, nil
+	}
+
+	endpoint := "/api/collections/" + collection + "/records/"
+	err = c.runBulk(ctx, "DELETE", result, opts, func(ctx context.Context, i int) (string, json.RawMessage, error) {
+		if _, err := c.doRequest(ctx, "DELETE", endpoint+ids[i], nil, opts...); err != nil {
+			return ids[i], nil, fmt.Errorf("failed to delete record: %w", err)
+		}
+		return ids[i], nil, nil
+	})
+	return result, err
+}
+
+// matchingIDs returns the IDs of all records matching filter, in ID order.
+func (c *Client) matchingIDs(ctx context.Context, collection, filter string, opts []RequestOption) ([]string, error) {
+	listOpts := append(opts[:len(opts):len(opts)], WithFilter(filter), WithFields("id"), WithSort("id"), WithSkipTotal())
+
+	var ids []string
+	var iterErr error
+	c.Iterate(ctx, collection, listOpts...)(func(item json.RawMessage, err error) bool {
+		if err != nil {
+			iterErr = err
+			return false
+		}
+		ids = append(ids, recordID(item))
+		return true
+	})
+	if iterErr != nil {
+		return nil, fmt.Errorf("failed to list matching records: %w", iterErr)
+	}
+	return ids, nil
+}
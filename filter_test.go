@@ -0,0 +1,30 @@
+package gopocketbaseclient
+
+import "testing"
+
+func TestQuoteFilterString(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{`plain`, `'plain'`},
+		{`it's`, `'it\'s'`},
+		{`C:\temp`, `'C:\temp'`},
+		{`C:\temp\\`, `'C:\temp'`},
+		{`\' || true || '`, `'\\' || true || \''`},
+	}
+	for _, tt := range tests {
+		if got := quoteFilterString(tt.in); got != tt.want {
+			t.Errorf("quoteFilterString(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestBuildFilterEscapesParams(t *testing.T) {
+	got := BuildFilter("name = {:name} && path = {:path}", map[string]interface{}{
+		"name": "O'Brien",
+		"path": `dir\`,
+	})
+	if want := `name = 'O\'Brien' && path = 'dir'`; got != want {
+		t.Errorf("BuildFilter() = %s, want %s", got, want)
+	}
+}
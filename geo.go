@@ -0,0 +1,53 @@
+package gopocketbaseclient
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// GeoPoint is the value of a PocketBase geoPoint field, encoded as
+// {"lon": ..., "lat": ...}.
+type GeoPoint struct {
+	Lon float64 `json:"lon"`
+	Lat float64 `json:"lat"`
+}
+
+// UnmarshalJSON also accepts null and "", which PocketBase may return for a
+// point that was never set, as the zero point.
+func (p *GeoPoint) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" || string(data) == `""` {
+		*p = GeoPoint{}
+		return nil
+	}
+
+	type alias GeoPoint
+	var a alias
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+	*p = GeoPoint(a)
+	return nil
+}
+
+// GeoDistanceLt matches records whose geoPoint field lies less than km
+// kilometres from the given coordinates.
+func GeoDistanceLt(field string, lon, lat, km float64) Filter {
+	return compare(geoDistance(field, lon, lat), "<", km)
+}
+
+// GeoDistanceLte matches records at most km kilometres away.
+func GeoDistanceLte(field string, lon, lat, km float64) Filter {
+	return compare(geoDistance(field, lon, lat), "<=", km)
+}
+
+// GeoDistanceGt matches records more than km kilometres away.
+func GeoDistanceGt(field string, lon, lat, km float64) Filter {
+	return compare(geoDistance(field, lon, lat), ">", km)
+}
+
+// geoDistance renders the geoDistance() filter function for a geoPoint field
+// and a fixed point.
+func geoDistance(field string, lon, lat float64) string {
+	return "geoDistance(" + field + ".lon, " + field + ".lat, " +
+		strconv.FormatFloat(lon, 'f', -1, 64) + ", " + strconv.FormatFloat(lat, 'f', -1, 64) + ")"
+}
@@ -0,0 +1,185 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Relation is one node of the relation tree fetched by Load.
+type Relation struct {
+	field  string
+	nested []Relation
+}
+
+// With selects a relation field to load, with the relations to load from the
+// related records in turn, e.g. With("project_id", With("owner_id")).
+// Back-relations use PocketBase's <collection>_via_<field> form.
+func With(field string, nested ...Relation) Relation {
+	return Relation{field: field, nested: nested}
+}
+
+// Load fetches a record together with its relation tree:
+//
+//	task, err := client.Load(ctx, "tasks", id, []Relation{
+//		With("project_id", With("owner_id")),
+//		With("assignee_id"),
+//	})
+//
+// Related records end up under expand, as with WithExpand. The tree is
+// expanded in the same request as far as PocketBase allows (MaxExpandDepth
+// levels); deeper levels are fetched afterwards with one batched query per
+// collection and level. Those follow-up queries rely on the collectionName of
+// the expanded records, so it must not be excluded with WithFields.
+func (c *Client) Load(ctx context.Context, collection, id string, relations []Relation, opts ...RequestOption) (map[string]interface{}, error) {
+	getOpts := opts
+	if paths := expandPaths("", relations, MaxExpandDepth); len(paths) > 0 {
+		getOpts = append(opts[:len(opts):len(opts)], WithExpand(paths...))
+	}
+
+	record, err := c.GetRecord(ctx, collection, id, getOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.loadDeep(ctx, []map[string]interface{}{record}, relations, 1, opts)
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// LoadInto is Load decoding the result into v, typically a struct using
+// pb:"expand=..." fields for the relations.
+func (c *Client) LoadInto(ctx context.Context, collection, id string, v interface{}, relations []Relation, opts ...RequestOption) error {
+	record, err := c.Load(ctx, collection, id, relations, opts...)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return c.decode(data, v)
+}
+
+// expandPaths renders relations as expand paths, cutting the tree after
+// depth levels.
+func expandPaths(prefix string, relations []Relation, depth int) []string {
+	var paths []string
+	for _, rel := range relations {
+		path := rel.field
+		if prefix != "" {
+			path = prefix + "." + rel.field
+		}
+		if len(rel.nested) == 0 || depth == 1 {
+			paths = append(paths, path)
+			continue
+		}
+		paths = append(paths, expandPaths(path, rel.nested, depth-1)...)
+	}
+	return paths
+}
+
+// loadDeep walks the expanded records level by level and fetches the parts
+// of the tree below MaxExpandDepth.
+func (c *Client) loadDeep(ctx context.Context, records []map[string]interface{}, relations []Relation, level int, opts []RequestOption) error {
+	for _, rel := range relations {
+		if len(rel.nested) == 0 {
+			continue
+		}
+
+		related := expandedRecords(records, rel.field)
+		if len(related) == 0 {
+			continue
+		}
+
+		next := level + 1
+		if level == MaxExpandDepth {
+			if err := c.expandRecords(ctx, related, rel.nested, opts); err != nil {
+				return err
+			}
+			next = 1
+		}
+		if err := c.loadDeep(ctx, related, rel.nested, next, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expandedRecords returns the records expanded under field.
+func expandedRecords(records []map[string]interface{}, field string) []map[string]interface{} {
+	var related []map[string]interface{}
+	for _, record := range records {
+		expand, _ := record["expand"].(map[string]interface{})
+		switch v := expand[field].(type) {
+		case map[string]interface{}:
+			related = append(related, v)
+		case []interface{}:
+			for _, item := range v {
+				if m, ok := item.(map[string]interface{}); ok {
+					related = append(related, m)
+				}
+			}
+		}
+	}
+	return related
+}
+
+// expandRecords fetches the expand tree of records that are already loaded,
+// batched per collection, and stores it on them.
+func (c *Client) expandRecords(ctx context.Context, records []map[string]interface{}, relations []Relation, opts []RequestOption) error {
+	byCollection := make(map[string]map[string][]map[string]interface{})
+	ids := make(map[string][]interface{})
+	var order []string
+	for _, record := range records {
+		collection, _ := record["collectionName"].(string)
+		id, _ := record["id"].(string)
+		if collection == "" || id == "" {
+			return errors.New("cannot load relations of a record without id and collectionName")
+		}
+		if byCollection[collection] == nil {
+			byCollection[collection] = make(map[string][]map[string]interface{})
+			order = append(order, collection)
+		}
+		if byCollection[collection][id] == nil {
+			ids[collection] = append(ids[collection], id)
+		}
+		byCollection[collection][id] = append(byCollection[collection][id], record)
+	}
+
+	paths := expandPaths("", relations, MaxExpandDepth)
+	for _, collection := range order {
+		byID, collectionIDs := byCollection[collection], ids[collection]
+		for start := 0; start < len(collectionIDs); start += relationLookupSize {
+			end := start + relationLookupSize
+			if end > len(collectionIDs) {
+				end = len(collectionIDs)
+			}
+
+			listOpts := append(opts[:len(opts):len(opts)], WithFilter(In("id", collectionIDs[start:end]...).String()), WithExpand(paths...))
+			list, err := c.GetFullList(ctx, collection, relationLookupSize, listOpts...)
+			if err != nil {
+				return fmt.Errorf("failed to load %s relations: %w", collection, err)
+			}
+
+			var items []map[string]interface{}
+			err = c.decode(list.Items, &items)
+			if err != nil {
+				return err
+			}
+			for _, item := range items {
+				id, _ := item["id"].(string)
+				for _, record := range byID[id] {
+					if expand, ok := item["expand"]; ok {
+						record["expand"] = expand
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package gopocketbaseclient
+
+import (
+	"net/http"
+	"runtime/debug"
+	"sync"
+)
+
+const modulePath = "github.com/ashkenazi1/gopocketbaseclient"
+
+// defaultUserAgent names the library and its version, e.g.
+// "gopocketbaseclient/v1.4.0", as found in the program's build info.
+var defaultUserAgent = sync.OnceValue(func() string {
+	version := "devel"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if info.Main.Path == modulePath && info.Main.Version != "" && info.Main.Version != "(devel)" {
+			version = info.Main.Version
+		}
+		for _, dep := range info.Deps {
+			if dep.Path == modulePath {
+				version = dep.Version
+			}
+		}
+	}
+	return "gopocketbaseclient/" + version
+})
+
+// WithUserAgent replaces the default User-Agent, gopocketbaseclient/<version>,
+// e.g. to name the application in the server's logs.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithDefaultHeader sets a header on every request, such as an API gateway
+// key or a tenant ID. Headers set with WithHeader take precedence; the
+// Authorization header is always the client's token.
+func WithDefaultHeader(key, value string) ClientOption {
+	return func(c *Client) {
+		if c.header == nil {
+			c.header = http.Header{}
+		}
+		c.header.Set(key, value)
+	}
+}
@@ -0,0 +1,38 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// HealthResponse is returned by GET /api/health. Data is only populated when
+// the request is made with superuser credentials.
+type HealthResponse struct {
+	Code    int        `json:"code"`
+	Message string     `json:"message"`
+	Data    HealthData `json:"data"`
+}
+
+type HealthData struct {
+	CanBackup           bool   `json:"canBackup"`
+	RealIP              string `json:"realIP"`
+	PossibleProxyHeader string `json:"possibleProxyHeader"`
+}
+
+// Health checks that the PocketBase instance is up, e.g. for readiness and
+// liveness probes.
+func (c *Client) Health(ctx context.Context, opts ...RequestOption) (*HealthResponse, error) {
+	respBody, err := c.doRequest(ctx, "GET", "/api/health", nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("health check failed: %w", err)
+	}
+
+	var health HealthResponse
+	err = json.Unmarshal(respBody, &health)
+	if err != nil {
+		return nil, err
+	}
+
+	return &health, nil
+}
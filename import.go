@@ -0,0 +1,258 @@
+package gopocketbaseclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldType tells ImportCollection how to convert an imported value.
+type FieldType int
+
+const (
+	FieldString FieldType = iota
+	FieldNumber
+	FieldBool
+	// FieldTime accepts RFC 3339, PocketBase's datetime layout and plain
+	// dates, and converts them to PocketBase's layout in UTC.
+	FieldTime
+	// FieldJSON parses a string as JSON, e.g. an array exported to CSV.
+	FieldJSON
+)
+
+// ImportOptions configures ImportCollection.
+type ImportOptions struct {
+	// Columns renames input columns (CSV header names or JSON keys) to
+	// field names. Columns mapped to "" are dropped.
+	Columns map[string]string
+	// Types converts the values of the named fields, after renaming. CSV
+	// values are strings otherwise; empty CSV values of typed fields become
+	// null.
+	Types map[string]FieldType
+	// BatchSize is the number of rows created per bulk call; defaults to
+	// DefaultBatchSize.
+	BatchSize int
+	// RequestOptions are passed to CreateMultipleRecords, e.g.
+	// WithConcurrency.
+	RequestOptions []RequestOption
+}
+
+// ImportCollection streams rows from r, in one of the formats written by
+// ExportCollection, and creates a record per row with the bulk machinery.
+// A CSV input starts with a header row. Rows that cannot be parsed or
+// created are reported in the result, with Index set to the 0-based data
+// row; the returned error is only set when reading r fails.
+func (c *Client) ImportCollection(ctx context.Context, collection string, r io.Reader, format ExportFormat, opts ImportOptions) (*BulkOperationResult, error) {
+	var next func() (map[string]interface{}, error)
+	switch format {
+	case ExportJSONL:
+		next = jsonlRows(r)
+	case ExportCSV:
+		next = csvRows(r)
+	default:
+		return nil, fmt.Errorf("unsupported import format %q", format)
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	result := newBulkResult(0)
+	var batch []map[string]interface{}
+	var batchRows []int
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		created, err := c.CreateMultipleRecords(ctx, collection, batch, opts.RequestOptions...)
+		if created != nil {
+			for i, item := range created.Results {
+				item.Index = batchRows[i]
+				result.Results[item.Index] = item
+			}
+			result.IDs = append(result.IDs, created.IDs...)
+			for _, bulkErr := range created.Errors {
+				bulkErr.Index = batchRows[bulkErr.Index]
+				result.Errors = append(result.Errors, bulkErr)
+			}
+		}
+		batch, batchRows = batch[:0], batchRows[:0]
+		return err
+	}
+
+	for row := 0; ; row++ {
+		record, err := next()
+		if err == io.EOF {
+			break
+		}
+		result.Results = append(result.Results, BulkItemResult{})
+		var rowErr *importRowError
+		if errors.As(err, &rowErr) {
+			result.failed(row, "", 0, rowErr.err)
+			continue
+		}
+		if err != nil {
+			result.Results = result.Results[:row]
+			if flushErr := flush(); flushErr != nil {
+				return result, flushErr
+			}
+			return result, fmt.Errorf("failed to read row %d: %w", row, err)
+		}
+
+		record, err = opts.convert(record)
+		if err != nil {
+			result.failed(row, "", 0, err)
+			continue
+		}
+
+		batch = append(batch, record)
+		batchRows = append(batchRows, row)
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+	return result, flush()
+}
+
+// importRowError is a malformed row that does not stop the import.
+type importRowError struct {
+	err error
+}
+
+func (e *importRowError) Error() string {
+	return e.err.Error()
+}
+
+func jsonlRows(r io.Reader) func() (map[string]interface{}, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	return func() (map[string]interface{}, error) {
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+
+			var record map[string]interface{}
+			decoder := json.NewDecoder(bytes.NewReader(line))
+			decoder.UseNumber()
+			if err := decoder.Decode(&record); err != nil {
+				return nil, &importRowError{err: err}
+			}
+			return record, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+}
+
+func csvRows(r io.Reader) func() (map[string]interface{}, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	var header []string
+	return func() (map[string]interface{}, error) {
+		if header == nil {
+			var err error
+			if header, err = reader.Read(); err != nil {
+				return nil, err
+			}
+		}
+
+		values, err := reader.Read()
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, &importRowError{err: err}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != len(header) {
+			return nil, &importRowError{err: fmt.Errorf("expected %d columns, got %d", len(header), len(values))}
+		}
+
+		record := make(map[string]interface{}, len(header))
+		for i, column := range header {
+			record[column] = values[i]
+		}
+		return record, nil
+	}
+}
+
+// convert renames and coerces the values of an imported row.
+func (o ImportOptions) convert(row map[string]interface{}) (map[string]interface{}, error) {
+	record := make(map[string]interface{}, len(row))
+	for column, value := range row {
+		field := column
+		if mapped, ok := o.Columns[column]; ok {
+			field = mapped
+		}
+		if field == "" {
+			continue
+		}
+
+		if fieldType, ok := o.Types[field]; ok {
+			converted, err := convertValue(value, fieldType)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", field, err)
+			}
+			value = converted
+		}
+		record[field] = value
+	}
+	return record, nil
+}
+
+func convertValue(value interface{}, fieldType FieldType) (interface{}, error) {
+	s, isString := value.(string)
+	if !isString {
+		return value, nil
+	}
+	if strings.TrimSpace(s) == "" && fieldType != FieldString {
+		return nil, nil
+	}
+
+	switch fieldType {
+	case FieldNumber:
+		return strconv.ParseFloat(strings.TrimSpace(s), 64)
+	case FieldBool:
+		return strconv.ParseBool(strings.TrimSpace(s))
+	case FieldTime:
+		t, err := parseImportTime(strings.TrimSpace(s))
+		if err != nil {
+			return nil, err
+		}
+		return t.UTC().Format(PocketBaseTimeLayout), nil
+	case FieldJSON:
+		var v interface{}
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+	return s, nil
+}
+
+func parseImportTime(s string) (time.Time, error) {
+	if t, err := parsePocketBaseTime(s); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as a time", s)
+}
@@ -0,0 +1,51 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ashkenazi1/gopocketbaseclient/pbtest"
+)
+
+func TestImportCollectionRowIndexes(t *testing.T) {
+	srv := pbtest.NewServer()
+	defer srv.Close()
+	client := NewClient(srv.URL, "")
+
+	input := strings.Join([]string{
+		`{"title":"row0"}`,
+		`{"title":`,
+		`{"title":"row2"}`,
+		`{"title":"row3"}`,
+		`{"title":"row4"}`,
+	}, "\n")
+	result, err := client.ImportCollection(context.Background(), "tasks", strings.NewReader(input), ExportJSONL, ImportOptions{BatchSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Errors) != 1 || result.Errors[0].Index != 1 {
+		t.Fatalf("errors = %v, want the malformed row 1", result.Errors)
+	}
+	for i, item := range result.Results {
+		if item.Index != i {
+			t.Errorf("Results[%d].Index = %d", i, item.Index)
+		}
+		if i == 1 {
+			continue
+		}
+		var record map[string]interface{}
+		if err := json.Unmarshal(item.Record, &record); err != nil {
+			t.Fatalf("Results[%d]: %v", i, err)
+		}
+		if want := fmt.Sprintf("row%d", i); record["title"] != want {
+			t.Errorf("Results[%d] holds %v, want %s", i, record["title"], want)
+		}
+	}
+	if n := len(srv.Records("tasks")); n != 4 {
+		t.Errorf("created %d records, want 4", n)
+	}
+}
@@ -0,0 +1,51 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// RecordReader is the read side of the records API. Application code can
+// accept it instead of *Client to be unit-tested against a mock.
+type RecordReader interface {
+	GetRecord(ctx context.Context, collection, id string, opts ...RequestOption) (map[string]interface{}, error)
+	GetRecords(ctx context.Context, collection string, filters map[string]string, opts ...RequestOption) (*JSONItems, error)
+	GetFirst(ctx context.Context, collection, filter, sortBy string, opts ...RequestOption) (map[string]interface{}, error)
+	GetList(ctx context.Context, collection string, page, perPage int, opts ...RequestOption) (*PaginatedResponse, error)
+	GetFullList(ctx context.Context, collection string, batchSize int, opts ...RequestOption) (*JSONItems, error)
+	All(ctx context.Context, collection string, opts ...RequestOption) (*JSONItems, error)
+	Iterate(ctx context.Context, collection string, opts ...RequestOption) func(yield func(json.RawMessage, error) bool)
+}
+
+// RecordWriter is the write side of the records API.
+type RecordWriter interface {
+	CreateRecord(ctx context.Context, collection string, record map[string]interface{}, opts ...RequestOption) error
+	CreateRecordAndReturn(ctx context.Context, collection string, record interface{}, opts ...RequestOption) (map[string]interface{}, error)
+	UpdateRecord(ctx context.Context, collection, id string, record map[string]interface{}, opts ...RequestOption) error
+	UpdateRecordAndReturn(ctx context.Context, collection, id string, record interface{}, opts ...RequestOption) (map[string]interface{}, error)
+	DeleteRecord(ctx context.Context, collection, id string, opts ...RequestOption) error
+}
+
+// AuthManager covers authentication and the session of the client.
+type AuthManager interface {
+	AuthWithPassword(ctx context.Context, collection, identity, password string) (*AuthResponse, error)
+	AuthWithPasswordMFA(ctx context.Context, collection, mfaID, identity, password string) (*AuthResponse, error)
+	AdminAuthWithPassword(ctx context.Context, email, password string) (*AuthResponse, error)
+	RequestOTP(ctx context.Context, collection, email string) (string, error)
+	AuthWithOTP(ctx context.Context, collection, otpID, password, mfaID string) (*AuthResponse, error)
+	TokenExpired() bool
+	Logout(ctx context.Context) error
+}
+
+// PocketBaseClient combines the interfaces above with raw access to custom
+// routes. It is implemented by *Client; prefer the smaller interfaces where
+// code needs only part of it.
+type PocketBaseClient interface {
+	RecordReader
+	RecordWriter
+	AuthManager
+	Send(ctx context.Context, method, path string, body interface{}, query url.Values, opts ...RequestOption) ([]byte, error)
+}
+
+var _ PocketBaseClient = (*Client)(nil)
@@ -0,0 +1,170 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Iterate lazily walks every record matching opts, fetching the next page only
+// when the consumer has processed the previous one. The returned function is
+// an iter.Seq2 and can be used with range-over-func:
+//
+//	for record, err := range client.Iterate(ctx, "tasks") {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// After an error is yielded the iteration stops. Totals are skipped, as with
+// GetFullList. Pages hold DefaultBatchSize records unless set with
+// WithPerPage, and the walk starts at the page set with WithPage, if any.
+func (c *Client) Iterate(ctx context.Context, collection string, opts ...RequestOption) func(yield func(json.RawMessage, error) bool) {
+	opts = append(opts[:len(opts):len(opts)], WithSkipTotal())
+	return func(yield func(json.RawMessage, error) bool) {
+		query := newRequestOptions(opts).query
+		requested := DefaultBatchSize
+		if n, err := strconv.Atoi(query.Get("perPage")); err == nil && n > 0 {
+			requested = n
+		}
+		first := 1
+		if n, err := strconv.Atoi(query.Get("page")); err == nil && n > 0 {
+			first = n
+		}
+
+		for page := first; ; page++ {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			list, err := c.GetList(ctx, collection, page, requested, opts...)
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to fetch page %d: %w", page, err))
+				return
+			}
+
+			var items []json.RawMessage
+			if err := json.Unmarshal(list.Items, &items); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if len(items) < list.PerPage || len(items) == 0 || (list.TotalPages >= 0 && page >= list.TotalPages) {
+				return
+			}
+		}
+	}
+}
+
+// IterateStream is Iterate for very large collections: each page response is
+// decoded item by item while it is read from the connection instead of being
+// buffered first, so memory stays flat however large the pages are. Use
+// WithPerPage to fetch bigger pages in fewer requests. Requests are not
+// retried and the client-wide timeout does not apply; use the context or
+// WithTimeout instead.
+func (c *Client) IterateStream(ctx context.Context, collection string, opts ...RequestOption) func(yield func(json.RawMessage, error) bool) {
+	opts = append(opts[:len(opts):len(opts)], WithSkipTotal())
+	return func(yield func(json.RawMessage, error) bool) {
+		requested := DefaultBatchSize
+		if n, err := strconv.Atoi(newRequestOptions(opts).query.Get("perPage")); err == nil && n > 0 {
+			requested = n
+		}
+
+		endpoint := "/api/collections/" + collection + "/records"
+		for page := 1; ; page++ {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			pageOpts := append(opts[:len(opts):len(opts)], WithPage(page), WithPerPage(requested))
+			resp, err := c.doStream(ctx, "GET", endpoint, nil, "", pageOpts...)
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to fetch page %d: %w", page, err))
+				return
+			}
+
+			count, perPage, totalPages, stopped, err := streamItems(json.NewDecoder(resp.Body), yield)
+			resp.Body.Close()
+			if stopped {
+				return
+			}
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to decode page %d: %w", page, err))
+				return
+			}
+
+			// PocketBase caps perPage, so compare against the page size it
+			// reports rather than the requested one.
+			if count < perPage || count == 0 || (totalPages >= 0 && page >= totalPages) {
+				return
+			}
+		}
+	}
+}
+
+// streamItems reads a list response, yielding every element of its items
+// array as soon as it is decoded. stopped reports that the consumer ended
+// the iteration.
+func streamItems(dec *json.Decoder, yield func(json.RawMessage, error) bool) (count, perPage, totalPages int, stopped bool, err error) {
+	totalPages = -1
+	if err := expectDelim(dec, '{'); err != nil {
+		return 0, 0, 0, false, err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return count, perPage, totalPages, false, err
+		}
+
+		switch tok {
+		case "perPage":
+			err = dec.Decode(&perPage)
+		case "totalPages":
+			err = dec.Decode(&totalPages)
+		case "items":
+			if err := expectDelim(dec, '['); err != nil {
+				return count, perPage, totalPages, false, err
+			}
+			for dec.More() {
+				var item json.RawMessage
+				if err := dec.Decode(&item); err != nil {
+					return count, perPage, totalPages, false, err
+				}
+				count++
+				if !yield(item, nil) {
+					return count, perPage, totalPages, true, nil
+				}
+			}
+			err = expectDelim(dec, ']')
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return count, perPage, totalPages, false, err
+		}
+	}
+	return count, perPage, totalPages, false, nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("expected %q, got %v", delim, tok)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ashkenazi1/gopocketbaseclient/pbtest"
+)
+
+// newCappedListServer serves total records with perPage capped at limit,
+// the way PocketBase caps oversized pages.
+func newCappedListServer(t *testing.T, total, limit int) *pbtest.Server {
+	t.Helper()
+	srv := pbtest.NewServer(pbtest.WithMaxPerPage(limit))
+	t.Cleanup(srv.Close)
+	for i := 0; i < total; i++ {
+		if _, err := srv.Insert("tasks", map[string]interface{}{"n": i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return srv
+}
+
+func TestGetFullListServerCapsPerPage(t *testing.T) {
+	srv := newCappedListServer(t, 5, 2)
+	client := NewClient(srv.URL, "")
+
+	list, err := client.GetFullList(context.Background(), "tasks", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(list.Items, &items); err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 5 {
+		t.Errorf("got %d records, want 5", len(items))
+	}
+}
+
+func TestIterateServerCapsPerPage(t *testing.T) {
+	srv := newCappedListServer(t, 5, 2)
+	client := NewClient(srv.URL, "")
+
+	iterators := map[string]func(context.Context, string, ...RequestOption) func(func(json.RawMessage, error) bool){
+		"Iterate":       client.Iterate,
+		"IterateStream": client.IterateStream,
+	}
+	for name, iterate := range iterators {
+		count := 0
+		iterate(context.Background(), "tasks", WithPerPage(10))(func(_ json.RawMessage, err error) bool {
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			count++
+			return true
+		})
+		if count != 5 {
+			t.Errorf("%s: got %d records, want 5", name, count)
+		}
+	}
+}
+
+func TestIterateHonoursPageOptions(t *testing.T) {
+	srv := newCappedListServer(t, 5, 100)
+	client := NewClient(srv.URL, "")
+
+	requests := 0
+	count := 0
+	client.Iterate(context.Background(), "tasks", WithPerPage(2), WithPage(2), WithDump(func(RequestDump) {
+		requests++
+	}))(func(_ json.RawMessage, err error) bool {
+		if err != nil {
+			t.Fatal(err)
+		}
+		count++
+		return true
+	})
+	if count != 3 {
+		t.Errorf("got %d records, want 3", count)
+	}
+	if requests != 2 {
+		t.Errorf("got %d requests, want 2", requests)
+	}
+}
@@ -0,0 +1,213 @@
+package gopocketbaseclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+// DecodeOption configures UnmarshalPocketBaseJSON.
+type DecodeOption func(*decodeOptions)
+
+type decodeOptions struct {
+	useNumber bool
+	lenient   bool
+	layouts   []string
+	location  *time.Location
+	codec     Codec
+}
+
+// UseNumber decodes numbers into interface{} values as json.Number instead of
+// float64, preserving the precision of large integer IDs and amounts.
+func UseNumber() DecodeOption {
+	return func(o *decodeOptions) {
+		o.useNumber = true
+	}
+}
+
+// TimeLayouts registers additional datetime layouts, e.g. "02.01.2006 15:04",
+// for values written by other tools. They apply to values decoded into
+// time.Time fields only.
+func TimeLayouts(layouts ...string) DecodeOption {
+	return func(o *decodeOptions) {
+		o.layouts = append(o.layouts, layouts...)
+	}
+}
+
+// TimeLocation sets the location of datetimes without zone information,
+// which are otherwise taken as UTC.
+func TimeLocation(loc *time.Location) DecodeOption {
+	return func(o *decodeOptions) {
+		o.location = loc
+	}
+}
+
+// EncodeOption configures MarshalPocketBaseJSON.
+type EncodeOption func(*encodeOptions)
+
+type encodeOptions struct {
+	timeOutput *TimeOutput
+	codec      Codec
+}
+
+// TimeFormat sets the datetime output format for a single encode instead of
+// the package-wide one set with SetTimeOutput.
+func TimeFormat(out TimeOutput) EncodeOption {
+	return func(o *encodeOptions) {
+		o.timeOutput = &out
+	}
+}
+
+// UnmarshalPocketBaseJSON decodes PocketBase JSON into v. Datetime strings in
+// PocketBase's format are decoded into time.Time fields, and empty strings
+// leave them zero; strings decoded into other types, including interface{}
+// and map values, are kept as they are. Struct fields tagged pb:"expand=<relation>" are
+// filled from the record's expand object.
+func UnmarshalPocketBaseJSON(data []byte, v interface{}, opts ...DecodeOption) error {
+	var o decodeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	data = rewriteTimes(data, reflect.TypeOf(v), &o)
+	if !o.lenient {
+		var err error
+		if o.codec != nil && !o.useNumber {
+			err = o.codec.Unmarshal(data, v)
+		} else {
+			err = newDecoder(data, o.useNumber).Decode(v)
+		}
+		if err != nil {
+			return err
+		}
+		if !hasExpandFields(reflect.TypeOf(v)) {
+			return nil
+		}
+	}
+
+	// Lenient decoding and expand fields work on the generic values.
+	var raw interface{}
+	if err := newDecoder(data, true).Decode(&raw); err != nil {
+		return err
+	}
+	if !o.lenient {
+		return fillExpands(raw, reflect.ValueOf(v), o)
+	}
+	return decodeRaw(raw, v, o)
+}
+
+// decodeRaw decodes generic JSON values into v.
+func decodeRaw(raw interface{}, v interface{}, o decodeOptions) error {
+	var err error
+	if o.lenient {
+		err = decodeLenient(raw, v, &o)
+	} else {
+		var normalized []byte
+		normalized, err = json.Marshal(raw)
+		if err == nil {
+			normalized = rewriteTimes(normalized, reflect.TypeOf(v), &o)
+			err = newDecoder(normalized, o.useNumber).Decode(v)
+		}
+	}
+	if err != nil && !o.lenient {
+		return err
+	}
+
+	if expandErr := fillExpands(raw, reflect.ValueOf(v), o); err == nil {
+		err = expandErr
+	}
+	return err
+}
+
+// MarshalPocketBaseJSON encodes v for a PocketBase request. It follows the
+// encoding/json rules, including omitempty, then writes time.Time and
+// PocketBaseTime values in PocketBase's datetime format (see SetTimeOutput)
+// and zero times as "", which PocketBase treats as an empty date. Strings are
+// never reformatted, even when they hold datetimes.
+func MarshalPocketBaseJSON(v interface{}, opts ...EncodeOption) ([]byte, error) {
+	var o encodeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	out := currentTimeOutput()
+	if o.timeOutput != nil {
+		out = *o.timeOutput
+	}
+
+	var codec Codec = StdCodec{}
+	if o.codec != nil {
+		codec = o.codec
+	}
+	data, err := codec.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return formatTimes(data, reflect.ValueOf(v), out), nil
+}
+
+// pocketBaseValue returns v as generic JSON values, with times formatted as
+// MarshalPocketBaseJSON does.
+func pocketBaseValue(v interface{}, opts []EncodeOption) (interface{}, error) {
+	data, err := MarshalPocketBaseJSON(v, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw interface{}
+	if err := newDecoder(data, true).Decode(&raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+func newDecoder(data []byte, useNumber bool) *json.Decoder {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if useNumber {
+		dec.UseNumber()
+	}
+	return dec
+}
+
+// parseTime parses PocketBase datetimes and the registered layouts.
+func (o *decodeOptions) parseTime(s string) (time.Time, bool) {
+	loc := time.UTC
+	if o.location != nil {
+		loc = o.location
+	}
+
+	if isPocketBaseDatetime(s) {
+		if t, err := parsePocketBaseTimeIn(s, loc); err == nil {
+			return t, true
+		}
+	}
+	for _, layout := range o.layouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func isPocketBaseDatetime(s string) bool {
+	return len(s) >= len("2006-01-02 15:04:05") && s[4] == '-' && s[10] == ' ' && s[13] == ':'
+}
+
+func (c *Client) decode(data []byte, v interface{}) error {
+	if c.codec == nil {
+		return UnmarshalPocketBaseJSON(data, v, c.decodeOptions...)
+	}
+	opts := append([]DecodeOption{DecodeWith(c.codec)}, c.decodeOptions...)
+	return UnmarshalPocketBaseJSON(data, v, opts...)
+}
+
+func (c *Client) encodeOptions() []EncodeOption {
+	var opts []EncodeOption
+	if c.timeOutput != nil {
+		opts = append(opts, TimeFormat(*c.timeOutput))
+	}
+	if c.codec != nil {
+		opts = append(opts, EncodeWith(c.codec))
+	}
+	return opts
+}
@@ -0,0 +1,106 @@
+package gopocketbaseclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type benchTask struct {
+	BaseRecord
+	Title    string    `json:"title"`
+	Done     bool      `json:"done"`
+	Priority int       `json:"priority"`
+	Due      time.Time `json:"due"`
+	Tags     []string  `json:"tags"`
+}
+
+// benchList returns a list response of n records as PocketBase sends it.
+func benchList(n int) []byte {
+	items := make([]map[string]interface{}, n)
+	for i := range items {
+		items[i] = map[string]interface{}{
+			"id":             fmt.Sprintf("rec%012d", i),
+			"collectionId":   "pbc_1234567890",
+			"collectionName": "tasks",
+			"created":        "2024-03-01 12:30:00.123Z",
+			"updated":        "2024-03-02 08:15:42.456Z",
+			"title":          fmt.Sprintf("Task number %d with a reasonably long title", i),
+			"done":           i%2 == 0,
+			"priority":       i % 5,
+			"due":            "2024-04-01 09:00:00.000Z",
+			"tags":           []string{"alpha", "beta"},
+		}
+	}
+	data, _ := json.Marshal(map[string]interface{}{"page": 1, "perPage": n, "totalItems": n, "totalPages": 1, "items": items})
+	return data
+}
+
+func BenchmarkUnmarshalPocketBaseJSONStructs(b *testing.B) {
+	data := benchList(500)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var list struct {
+			Items []benchTask `json:"items"`
+		}
+		if err := UnmarshalPocketBaseJSON(data, &list); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalPocketBaseJSONMaps(b *testing.B) {
+	data := benchList(500)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var list struct {
+			Items []map[string]interface{} `json:"items"`
+		}
+		if err := UnmarshalPocketBaseJSON(data, &list); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchTasks(n int) []benchTask {
+	due := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	tasks := make([]benchTask, n)
+	for i := range tasks {
+		tasks[i] = benchTask{
+			BaseRecord: BaseRecord{ID: fmt.Sprintf("rec%012d", i), CollectionName: "tasks"},
+			Title:      fmt.Sprintf("Task number %d with a reasonably long title", i),
+			Done:       i%2 == 0,
+			Priority:   i % 5,
+			Due:        due,
+			Tags:       []string{"alpha", "beta"},
+		}
+	}
+	return tasks
+}
+
+func BenchmarkMarshalPocketBaseJSONStructs(b *testing.B) {
+	tasks := benchTasks(500)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := MarshalPocketBaseJSON(tasks); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalPocketBaseJSONMaps(b *testing.B) {
+	due := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	records := make([]map[string]interface{}, 500)
+	for i := range records {
+		records[i] = map[string]interface{}{"title": fmt.Sprintf("Task %d", i), "done": i%2 == 0, "due": due}
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := MarshalPocketBaseJSON(records); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
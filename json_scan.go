@@ -0,0 +1,468 @@
+package gopocketbaseclient
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UnmarshalPocketBaseJSON and MarshalPocketBaseJSON only rewrite datetimes
+// of time-typed values: the JSON is walked alongside the type it is decoded
+// into or the value it was encoded from, so strings in text fields, json
+// columns and maps are left as they are.
+
+// jsonEdit replaces data[start:end] with value.
+type jsonEdit struct {
+	start, end int
+	value      []byte
+}
+
+func applyEdits(data []byte, edits []jsonEdit) []byte {
+	if len(edits) == 0 {
+		return data
+	}
+	out := make([]byte, 0, len(data)+len(data)/8)
+	last := 0
+	for _, e := range edits {
+		out = append(out, data[last:e.start]...)
+		out = append(out, e.value...)
+		last = e.end
+	}
+	return append(out, data[last:]...)
+}
+
+func skipSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+// scanString returns the end of the string starting at data[i] and whether
+// it contains escape sequences, or -1 if it is unterminated.
+func scanString(data []byte, i int) (int, bool) {
+	escaped := false
+	for i++; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			escaped = true
+			i++
+		case '"':
+			return i + 1, escaped
+		}
+	}
+	return -1, escaped
+}
+
+// skipValue returns the end of the value starting at data[i], or -1 for
+// malformed input, which the decoder then reports.
+func skipValue(data []byte, i int) int {
+	i = skipSpace(data, i)
+	if i >= len(data) {
+		return -1
+	}
+	switch data[i] {
+	case '"':
+		end, _ := scanString(data, i)
+		return end
+	case '{', '[':
+		depth := 0
+		for ; i < len(data); i++ {
+			switch data[i] {
+			case '"':
+				end, _ := scanString(data, i)
+				if end < 0 {
+					return -1
+				}
+				i = end - 1
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+		}
+		return -1
+	}
+	for ; i < len(data); i++ {
+		switch data[i] {
+		case ',', '}', ']', ' ', '\t', '\n', '\r':
+			return i
+		}
+	}
+	return i
+}
+
+// scanObject calls member for every key of the object starting at data[i]
+// with the position of its value; member returns the end of the value. Keys
+// with escape sequences are passed as nil. It returns the end of the object.
+func scanObject(data []byte, i int, member func(key []byte, value int) int) int {
+	for i++; ; {
+		i = skipSpace(data, i)
+		if i >= len(data) {
+			return -1
+		}
+		switch data[i] {
+		case '}':
+			return i + 1
+		case ',':
+			i++
+			continue
+		case '"':
+		default:
+			return -1
+		}
+
+		end, escaped := scanString(data, i)
+		if end < 0 {
+			return -1
+		}
+		key := data[i+1 : end-1]
+		if escaped {
+			key = nil
+		}
+		i = skipSpace(data, end)
+		if i >= len(data) || data[i] != ':' {
+			return -1
+		}
+		if i = member(key, skipSpace(data, i+1)); i < 0 {
+			return -1
+		}
+	}
+}
+
+// scanArray calls item for every element of the array starting at data[i];
+// item returns the end of the element. It returns the end of the array.
+func scanArray(data []byte, i int, item func(index, value int) int) int {
+	for i, n := i+1, 0; ; {
+		i = skipSpace(data, i)
+		if i >= len(data) {
+			return -1
+		}
+		switch data[i] {
+		case ']':
+			return i + 1
+		case ',':
+			i++
+			continue
+		}
+		if i = item(n, i); i < 0 {
+			return -1
+		}
+		n++
+	}
+}
+
+// jsonField is a struct field as encoding/json sees it.
+type jsonField struct {
+	index []int
+	typ   reflect.Type
+}
+
+var jsonFieldsCache sync.Map // reflect.Type -> map[string]jsonField
+
+// jsonFields maps the JSON names of t's fields to the fields, with fields of
+// embedded structs promoted unless the outer struct has the same name.
+func jsonFields(t reflect.Type) map[string]jsonField {
+	if fields, ok := jsonFieldsCache.Load(t); ok {
+		return fields.(map[string]jsonField)
+	}
+
+	fields := make(map[string]jsonField)
+	promoted := make(map[string]jsonField)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for inner, f := range jsonFields(embedded) {
+					promoted[inner] = jsonField{index: append([]int{i}, f.index...), typ: f.typ}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = jsonField{index: []int{i}, typ: field.Type}
+	}
+	for name, f := range promoted {
+		if _, ok := fields[name]; !ok {
+			fields[name] = f
+		}
+	}
+
+	jsonFieldsCache.Store(t, fields)
+	return fields
+}
+
+// lookupJSONField finds the field for key, falling back to the
+// case-insensitive match encoding/json also accepts.
+func lookupJSONField(fields map[string]jsonField, key []byte) (jsonField, bool) {
+	if f, ok := fields[string(key)]; ok {
+		return f, true
+	}
+	for name, f := range fields {
+		if strings.EqualFold(name, string(key)) {
+			return f, true
+		}
+	}
+	return jsonField{}, false
+}
+
+var timeFieldTypes sync.Map // reflect.Type -> bool
+
+// hasTimeFields reports whether values of t can hold a time.Time that
+// encoding/json decodes itself, i.e. one not behind a custom unmarshaler.
+func hasTimeFields(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+	if v, ok := timeFieldTypes.Load(t); ok {
+		return v.(bool)
+	}
+	found := scanTimeFields(t, make(map[reflect.Type]bool))
+	timeFieldTypes.Store(t, found)
+	return found
+}
+
+func scanTimeFields(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if t == timeType {
+		return true
+	}
+	if seen[t] || reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		return false
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return scanTimeFields(t.Elem(), seen)
+	case reflect.Struct:
+		for _, f := range jsonFields(t) {
+			if scanTimeFields(f.typ, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rewriteTimes returns data with the datetimes that decode into time.Time
+// values of t rewritten to RFC3339, the form time.Time decodes. Empty
+// strings, PocketBase's empty date, become null and leave the time zero.
+func rewriteTimes(data []byte, t reflect.Type, o *decodeOptions) []byte {
+	if !hasTimeFields(t) {
+		return data
+	}
+	r := timeRewriter{data: data, o: o}
+	if r.value(t, 0) < 0 {
+		return data
+	}
+	return applyEdits(data, r.edits)
+}
+
+type timeRewriter struct {
+	data  []byte
+	o     *decodeOptions
+	edits []jsonEdit
+}
+
+func (r *timeRewriter) value(t reflect.Type, i int) int {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	data := r.data
+	i = skipSpace(data, i)
+	if i >= len(data) {
+		return -1
+	}
+
+	if t == timeType {
+		if data[i] != '"' {
+			return skipValue(data, i)
+		}
+		end, escaped := scanString(data, i)
+		if end < 0 || escaped {
+			return end
+		}
+		s := string(data[i+1 : end-1])
+		if s == "" {
+			r.edits = append(r.edits, jsonEdit{i, end, []byte("null")})
+		} else if parsed, ok := r.o.parseTime(s); ok {
+			value := append([]byte{'"'}, parsed.AppendFormat(nil, time.RFC3339Nano)...)
+			r.edits = append(r.edits, jsonEdit{i, end, append(value, '"')})
+		}
+		return end
+	}
+	if !hasTimeFields(t) {
+		return skipValue(data, i)
+	}
+
+	switch {
+	case t.Kind() == reflect.Struct && data[i] == '{':
+		fields := jsonFields(t)
+		return scanObject(data, i, func(key []byte, value int) int {
+			if f, ok := lookupJSONField(fields, key); ok && key != nil {
+				return r.value(f.typ, value)
+			}
+			return skipValue(data, value)
+		})
+	case t.Kind() == reflect.Map && data[i] == '{':
+		return scanObject(data, i, func(_ []byte, value int) int {
+			return r.value(t.Elem(), value)
+		})
+	case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && data[i] == '[':
+		return scanArray(data, i, func(_, value int) int {
+			return r.value(t.Elem(), value)
+		})
+	}
+	return skipValue(data, i)
+}
+
+var (
+	pocketBaseTimeType = reflect.TypeOf(PocketBaseTime{})
+	jsonMarshalerType  = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+var encodeTimeTypes sync.Map // reflect.Type -> bool
+
+// mayHoldTimes reports whether encoding a value of t can produce a
+// time.Time or PocketBaseTime: either directly, through fields and elements
+// without a custom marshaler, or through an interface.
+func mayHoldTimes(t reflect.Type) bool {
+	if v, ok := encodeTimeTypes.Load(t); ok {
+		return v.(bool)
+	}
+	found := scanEncodeTimes(t, make(map[reflect.Type]bool))
+	encodeTimeTypes.Store(t, found)
+	return found
+}
+
+func scanEncodeTimes(t reflect.Type, seen map[reflect.Type]bool) bool {
+	switch {
+	case t == timeType || t == pocketBaseTimeType || t.Kind() == reflect.Interface:
+		return true
+	case seen[t] || t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return false
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return scanEncodeTimes(t.Elem(), seen)
+	case reflect.Struct:
+		for _, f := range jsonFields(t) {
+			if scanEncodeTimes(f.typ, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// formatTimes returns data, the encoding of v, with the time.Time and
+// PocketBaseTime values of v written in out's format. Zero times are
+// written as "", PocketBase's empty date. Strings of other types are left
+// alone, even when they look like datetimes.
+func formatTimes(data []byte, v reflect.Value, out TimeOutput) []byte {
+	if !v.IsValid() || !mayHoldTimes(v.Type()) {
+		return data
+	}
+	f := timeFormatter{data: data, out: out}
+	if f.value(v, 0) < 0 {
+		return data
+	}
+	return applyEdits(data, f.edits)
+}
+
+type timeFormatter struct {
+	data  []byte
+	out   TimeOutput
+	edits []jsonEdit
+}
+
+func (f *timeFormatter) value(v reflect.Value, i int) int {
+	data := f.data
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return skipValue(data, i)
+		}
+		v = v.Elem()
+	}
+	i = skipSpace(data, i)
+	if i >= len(data) {
+		return -1
+	}
+
+	if (v.Type() == timeType || v.Type() == pocketBaseTimeType) && v.CanInterface() {
+		if data[i] != '"' {
+			return skipValue(data, i)
+		}
+		end, _ := scanString(data, i)
+		if end < 0 {
+			return -1
+		}
+
+		var t time.Time
+		if pt, ok := v.Interface().(PocketBaseTime); ok {
+			t = pt.Time
+		} else {
+			t = v.Interface().(time.Time)
+		}
+		value := []byte(`""`)
+		if !t.IsZero() {
+			value = append(append([]byte{'"'}, f.out.format(t)...), '"')
+		}
+		f.edits = append(f.edits, jsonEdit{i, end, value})
+		return end
+	}
+	if !mayHoldTimes(v.Type()) {
+		return skipValue(data, i)
+	}
+
+	switch {
+	case v.Kind() == reflect.Struct && data[i] == '{':
+		fields := jsonFields(v.Type())
+		return scanObject(data, i, func(key []byte, value int) int {
+			if field, ok := fields[string(key)]; ok && key != nil {
+				if fv, err := v.FieldByIndexErr(field.index); err == nil {
+					return f.value(fv, value)
+				}
+			}
+			return skipValue(data, value)
+		})
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String && data[i] == '{':
+		keyType := v.Type().Key()
+		return scanObject(data, i, func(key []byte, value int) int {
+			if key != nil {
+				if item := v.MapIndex(reflect.ValueOf(string(key)).Convert(keyType)); item.IsValid() {
+					return f.value(item, value)
+				}
+			}
+			return skipValue(data, value)
+		})
+	case (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && data[i] == '[':
+		return scanArray(data, i, func(index, value int) int {
+			if index < v.Len() {
+				return f.value(v.Index(index), value)
+			}
+			return skipValue(data, value)
+		})
+	}
+	return skipValue(data, i)
+}
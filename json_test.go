@@ -0,0 +1,134 @@
+package gopocketbaseclient
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestUnmarshalPocketBaseJSONTimes(t *testing.T) {
+	data := []byte(`{
+		"id": "abc",
+		"due": "2024-03-01 12:30:00.123Z",
+		"deleted": "",
+		"note": "2024-03-01 12:30:00.123Z",
+		"history": ["2024-01-02 03:04:05.000Z"],
+		"meta": {"seen": "2024-03-01 12:30:00.000Z"}
+	}`)
+
+	var record struct {
+		ID      string                 `json:"id"`
+		Due     time.Time              `json:"due"`
+		Deleted time.Time              `json:"deleted"`
+		Note    string                 `json:"note"`
+		History []time.Time            `json:"history"`
+		Meta    map[string]interface{} `json:"meta"`
+	}
+	if err := UnmarshalPocketBaseJSON(data, &record); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := time.Date(2024, 3, 1, 12, 30, 0, 123e6, time.UTC); !record.Due.Equal(want) {
+		t.Errorf("Due = %v, want %v", record.Due, want)
+	}
+	if !record.Deleted.IsZero() {
+		t.Errorf("Deleted = %v, want zero", record.Deleted)
+	}
+	if want := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC); len(record.History) != 1 || !record.History[0].Equal(want) {
+		t.Errorf("History = %v, want [%v]", record.History, want)
+	}
+	if record.Note != "2024-03-01 12:30:00.123Z" {
+		t.Errorf("Note = %q, want it unchanged", record.Note)
+	}
+	if got := record.Meta["seen"]; got != "2024-03-01 12:30:00.000Z" {
+		t.Errorf(`Meta["seen"] = %v, want it unchanged`, got)
+	}
+}
+
+func TestUnmarshalPocketBaseJSONMapUnchanged(t *testing.T) {
+	var record map[string]interface{}
+	if err := UnmarshalPocketBaseJSON([]byte(`{"updated":"2024-03-01 12:30:00.000Z"}`), &record); err != nil {
+		t.Fatal(err)
+	}
+	if got := record["updated"]; got != "2024-03-01 12:30:00.000Z" {
+		t.Errorf("updated = %v, want it unchanged", got)
+	}
+}
+
+func TestUnmarshalPocketBaseJSONLenientTimes(t *testing.T) {
+	var record struct {
+		Due   time.Time `json:"due"`
+		Count int       `json:"count"`
+	}
+	data := []byte(`{"due":"01.03.2024 12:30","count":"3"}`)
+	if err := UnmarshalPocketBaseJSON(data, &record, Lenient(), TimeLayouts("02.01.2006 15:04")); err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC); !record.Due.Equal(want) || record.Count != 3 {
+		t.Errorf("got %v, %d; want %v, 3", record.Due, record.Count, want)
+	}
+}
+
+func TestUnmarshalPocketBaseJSONLenientNestedNumbers(t *testing.T) {
+	var record struct {
+		Data interface{} `json:"data"`
+	}
+	data := []byte(`{"data":{"count":3,"items":[1.5,{"n":2}]}}`)
+	if err := UnmarshalPocketBaseJSON(data, &record, Lenient()); err != nil {
+		t.Fatal(err)
+	}
+
+	got := record.Data.(map[string]interface{})
+	items := got["items"].([]interface{})
+	if _, ok := got["count"].(float64); !ok {
+		t.Errorf("count is %T, want float64", got["count"])
+	}
+	if _, ok := items[0].(float64); !ok {
+		t.Errorf("items[0] is %T, want float64", items[0])
+	}
+	if n := items[1].(map[string]interface{})["n"]; n != 2.0 {
+		t.Errorf("items[1].n = %#v, want float64 2", n)
+	}
+}
+
+func TestMarshalPocketBaseJSONTimes(t *testing.T) {
+	due := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	v := map[string]interface{}{
+		"due":     due,
+		"pbDue":   PocketBaseTime{due},
+		"deleted": time.Time{},
+		"note":    "2024-03-01T12:30:00Z",
+		"nested":  []interface{}{&due},
+		"task": struct {
+			Start time.Time `json:"start"`
+			Label string    `json:"label"`
+		}{due, "2024-03-01 12:30:00.000Z"},
+	}
+
+	data, err := MarshalPocketBaseJSON(v, TimeFormat(TimeOutput{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	const want = "2024-03-01 12:30:00.000Z"
+	if got["due"] != want || got["pbDue"] != want {
+		t.Errorf("due = %v, pbDue = %v, want %s", got["due"], got["pbDue"], want)
+	}
+	if got["deleted"] != "" {
+		t.Errorf("deleted = %v, want empty", got["deleted"])
+	}
+	if got["note"] != "2024-03-01T12:30:00Z" {
+		t.Errorf("note = %v, want it unchanged", got["note"])
+	}
+	if nested := got["nested"].([]interface{}); nested[0] != want {
+		t.Errorf("nested = %v, want [%s]", nested, want)
+	}
+	task := got["task"].(map[string]interface{})
+	if task["start"] != want || task["label"] != "2024-03-01 12:30:00.000Z" {
+		t.Errorf("task = %v", task)
+	}
+}
@@ -0,0 +1,404 @@
+package gopocketbaseclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Lenient enables tolerant decoding: numeric strings are coerced into numbers,
+// "true"/"false"/0/1 into bools and single values into one-element slices.
+// Fields that still cannot be decoded are reported in DecodeErrors while the
+// remaining fields are populated.
+func Lenient() DecodeOption {
+	return func(o *decodeOptions) {
+		o.lenient = true
+	}
+}
+
+// DecodeFieldError describes a single field that could not be decoded.
+type DecodeFieldError struct {
+	Field string
+	Err   error
+}
+
+func (e DecodeFieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+// DecodeErrors is returned by lenient decoding when one or more fields could
+// not be coerced into their target types.
+type DecodeErrors []DecodeFieldError
+
+func (e DecodeErrors) Error() string {
+	parts := make([]string, len(e))
+	for i, fe := range e {
+		parts[i] = fe.Error()
+	}
+	return "failed to decode fields: " + strings.Join(parts, "; ")
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	timeType            = reflect.TypeOf(time.Time{})
+)
+
+func decodeLenient(raw interface{}, v interface{}, o *decodeOptions) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("lenient decode requires a non-nil pointer, got %T", v)
+	}
+
+	d := &lenientDecoder{useNumber: o.useNumber, opts: o}
+	d.decode("", raw, rv.Elem())
+	if len(d.errs) > 0 {
+		return d.errs
+	}
+	return nil
+}
+
+type lenientDecoder struct {
+	useNumber bool
+	opts      *decodeOptions
+	errs      DecodeErrors
+}
+
+func (d *lenientDecoder) fail(path string, err error) {
+	if path == "" {
+		path = "."
+	}
+	d.errs = append(d.errs, DecodeFieldError{Field: path, Err: err})
+}
+
+func (d *lenientDecoder) decode(path string, src interface{}, dst reflect.Value) {
+	if dst.Kind() == reflect.Ptr {
+		if src == nil {
+			dst.Set(reflect.Zero(dst.Type()))
+			return
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		d.decode(path, src, dst.Elem())
+		return
+	}
+
+	if dst.Type() == timeType {
+		d.decodeTime(path, src, dst)
+		return
+	}
+	if reflect.PointerTo(dst.Type()).Implements(jsonUnmarshalerType) {
+		d.decodeJSON(path, src, dst)
+		return
+	}
+
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return
+	}
+
+	switch dst.Kind() {
+	case reflect.Interface:
+		if !d.useNumber {
+			var err error
+			if src, err = floatNumbers(src); err != nil {
+				d.fail(path, err)
+				return
+			}
+		}
+		val := reflect.ValueOf(src)
+		if !val.Type().AssignableTo(dst.Type()) {
+			d.fail(path, fmt.Errorf("cannot assign %T to %s", src, dst.Type()))
+			return
+		}
+		dst.Set(val)
+	case reflect.String:
+		switch s := src.(type) {
+		case string:
+			dst.SetString(s)
+		case json.Number:
+			dst.SetString(s.String())
+		case bool:
+			dst.SetString(strconv.FormatBool(s))
+		default:
+			d.fail(path, fmt.Errorf("cannot coerce %T into string", src))
+		}
+	case reflect.Bool:
+		b, err := coerceBool(src)
+		if err != nil {
+			d.fail(path, err)
+			return
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := coerceInt(src)
+		if err == nil && dst.OverflowInt(n) {
+			err = fmt.Errorf("value %d overflows %s", n, dst.Type())
+		}
+		if err != nil {
+			d.fail(path, err)
+			return
+		}
+		dst.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := coerceInt(src)
+		if err == nil && (n < 0 || dst.OverflowUint(uint64(n))) {
+			err = fmt.Errorf("value %d overflows %s", n, dst.Type())
+		}
+		if err != nil {
+			d.fail(path, err)
+			return
+		}
+		dst.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		f, err := coerceFloat(src)
+		if err == nil && dst.OverflowFloat(f) {
+			err = fmt.Errorf("value %v overflows %s", f, dst.Type())
+		}
+		if err != nil {
+			d.fail(path, err)
+			return
+		}
+		dst.SetFloat(f)
+	case reflect.Slice:
+		d.decodeSlice(path, src, dst)
+	case reflect.Map:
+		d.decodeMap(path, src, dst)
+	case reflect.Struct:
+		d.decodeStruct(path, src, dst)
+	default:
+		d.decodeJSON(path, src, dst)
+	}
+}
+
+// decodeTime accepts PocketBase datetimes and the registered layouts, and
+// leaves the time zero for PocketBase's empty date.
+func (d *lenientDecoder) decodeTime(path string, src interface{}, dst reflect.Value) {
+	if s, ok := src.(string); ok {
+		if s == "" {
+			dst.Set(reflect.Zero(dst.Type()))
+			return
+		}
+		if t, ok := d.opts.parseTime(s); ok {
+			dst.Set(reflect.ValueOf(t))
+			return
+		}
+	}
+	d.decodeJSON(path, src, dst)
+}
+
+func (d *lenientDecoder) decodeJSON(path string, src interface{}, dst reflect.Value) {
+	data, err := json.Marshal(src)
+	if err != nil {
+		d.fail(path, err)
+		return
+	}
+	if err := json.Unmarshal(data, dst.Addr().Interface()); err != nil {
+		d.fail(path, err)
+	}
+}
+
+func (d *lenientDecoder) decodeSlice(path string, src interface{}, dst reflect.Value) {
+	var items []interface{}
+	switch s := src.(type) {
+	case []interface{}:
+		items = s
+	case string:
+		if s == "" {
+			dst.Set(reflect.MakeSlice(dst.Type(), 0, 0))
+			return
+		}
+		items = []interface{}{s}
+	default:
+		items = []interface{}{s}
+	}
+
+	out := reflect.MakeSlice(dst.Type(), len(items), len(items))
+	for i, item := range items {
+		d.decode(fmt.Sprintf("%s[%d]", path, i), item, out.Index(i))
+	}
+	dst.Set(out)
+}
+
+func (d *lenientDecoder) decodeMap(path string, src interface{}, dst reflect.Value) {
+	m, ok := src.(map[string]interface{})
+	if !ok || dst.Type().Key().Kind() != reflect.String {
+		d.decodeJSON(path, src, dst)
+		return
+	}
+
+	out := reflect.MakeMapWithSize(dst.Type(), len(m))
+	for k, item := range m {
+		elem := reflect.New(dst.Type().Elem()).Elem()
+		d.decode(joinPath(path, k), item, elem)
+		out.SetMapIndex(reflect.ValueOf(k).Convert(dst.Type().Key()), elem)
+	}
+	dst.Set(out)
+}
+
+func (d *lenientDecoder) decodeStruct(path string, src interface{}, dst reflect.Value) {
+	m, ok := src.(map[string]interface{})
+	if !ok {
+		d.fail(path, fmt.Errorf("cannot coerce %T into %s", src, dst.Type()))
+		return
+	}
+
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			d.decodeStruct(path, src, dst.Field(i))
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		value, found := lookupField(m, name)
+		if !found {
+			continue
+		}
+		d.decode(joinPath(path, name), value, dst.Field(i))
+	}
+}
+
+// floatNumbers converts the json.Number values in v, including those nested
+// in objects and arrays, to float64 as encoding/json decodes them without
+// UseNumber.
+func floatNumbers(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case json.Number:
+		return v.Float64()
+	case map[string]interface{}:
+		for k, item := range v {
+			converted, err := floatNumbers(item)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = converted
+		}
+	case []interface{}:
+		for i, item := range v {
+			converted, err := floatNumbers(item)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = converted
+		}
+	}
+	return v, nil
+}
+
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name, false
+}
+
+func lookupField(m map[string]interface{}, name string) (interface{}, bool) {
+	if v, ok := m[name]; ok {
+		return v, true
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func coerceBool(src interface{}) (bool, error) {
+	switch v := src.(type) {
+	case bool:
+		return v, nil
+	case json.Number:
+		switch v.String() {
+		case "0":
+			return false, nil
+		case "1":
+			return true, nil
+		}
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "1":
+			return true, nil
+		case "false", "0", "":
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("cannot coerce %v into bool", src)
+}
+
+func coerceInt(src interface{}) (int64, error) {
+	var s string
+	switch v := src.(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		s = strings.TrimSpace(v)
+		if s == "" {
+			return 0, nil
+		}
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("cannot coerce %T into integer", src)
+	}
+
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f != math.Trunc(f) || f > math.MaxInt64 || f < math.MinInt64 {
+		return 0, fmt.Errorf("cannot coerce %q into integer", s)
+	}
+	return int64(f), nil
+}
+
+func coerceFloat(src interface{}) (float64, error) {
+	switch v := src.(type) {
+	case json.Number:
+		return v.Float64()
+	case string:
+		s := strings.TrimSpace(v)
+		if s == "" {
+			return 0, nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("cannot coerce %q into float", s)
+		}
+		return f, nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return 0, fmt.Errorf("cannot coerce %T into float", src)
+}
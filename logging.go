@@ -0,0 +1,101 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+func (c *Client) logRequest(ctx context.Context, method, endpoint, requestID string, status int, reqBody, respBody []byte, err error, latency time.Duration) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		respBody = apiErr.Body
+	}
+
+	rawURL := c.url(endpoint)
+	loggedURL := redactURL(rawURL)
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("url", loggedURL),
+		slog.String("request_id", requestID),
+		slog.Int("status", status),
+		slog.Duration("latency", latency),
+	}
+	if c.logBodies {
+		attrs = append(attrs,
+			slog.String("request_body", redactBody(reqBody)),
+			slog.String("response_body", redactBody(respBody)),
+		)
+	}
+
+	if err != nil {
+		// Transport errors quote the request URL.
+		attrs = append(attrs, slog.String("error", strings.ReplaceAll(err.Error(), rawURL, loggedURL)))
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "pocketbase request failed", attrs...)
+		return
+	}
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "pocketbase request", attrs...)
+}
+
+// redactURL masks secret query parameters, such as the token of protected
+// file URLs, keeping the order and encoding of the others.
+func redactURL(rawURL string) string {
+	base, query, ok := strings.Cut(rawURL, "?")
+	if !ok {
+		return rawURL
+	}
+
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		key, _, _ := strings.Cut(param, "=")
+		if name, err := url.QueryUnescape(key); err == nil && isSecretKey(name) {
+			params[i] = key + "=" + redacted
+		}
+	}
+	return base + "?" + strings.Join(params, "&")
+}
+
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return string(body)
+	}
+	return string(out)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			if isSecretKey(k) {
+				val[k] = redacted
+				continue
+			}
+			val[k] = redactValue(item)
+		}
+	case []interface{}:
+		for i, item := range val {
+			val[i] = redactValue(item)
+		}
+	}
+	return v
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	return strings.Contains(key, "password") || strings.Contains(key, "secret") || key == "token"
+}
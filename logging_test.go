@@ -0,0 +1,46 @@
+package gopocketbaseclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ashkenazi1/gopocketbaseclient/pbtest"
+)
+
+func TestLogRequestStatusAndRedactedURL(t *testing.T) {
+	srv := pbtest.NewServer()
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient(srv.URL, "", WithLogger(logger))
+
+	query := url.Values{"thumb": {"100x100"}, "token": {"file-secret"}}
+	_, err := client.Send(context.Background(), "GET", "/api/files/tasks/abc/report.pdf", nil, query)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if strings.Contains(buf.String(), "file-secret") {
+		t.Errorf("log contains the file token: %s", buf.String())
+	}
+	var entry struct {
+		Status int    `json:"status"`
+		URL    string `json:"url"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Status != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", entry.Status)
+	}
+	if want := srv.URL + "/api/files/tasks/abc/report.pdf?thumb=100x100&token=" + redacted; entry.URL != want {
+		t.Errorf("url = %s, want %s", entry.URL, want)
+	}
+}
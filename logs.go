@@ -0,0 +1,75 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// LogModel is a single PocketBase log entry.
+type LogModel struct {
+	ID      string                 `json:"id"`
+	Created string                 `json:"created"`
+	Level   int                    `json:"level"`
+	Message string                 `json:"message"`
+	Data    map[string]interface{} `json:"data"`
+}
+
+// LogsStat is the number of log entries in one hourly bucket.
+type LogsStat struct {
+	Date  string `json:"date"`
+	Total int    `json:"total"`
+}
+
+// ListLogs returns a page of log entries. filter and sort are optional.
+func (c *Client) ListLogs(ctx context.Context, filter, sort string, page, perPage int, opts ...RequestOption) (*ListResult[LogModel], error) {
+	opts = append(opts[:len(opts):len(opts)], WithFilter(filter), WithPage(page), WithPerPage(perPage))
+	if sort != "" {
+		opts = append(opts, WithSort(sort))
+	}
+
+	respBody, err := c.doRequest(ctx, "GET", "/api/logs", nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list logs: %w", err)
+	}
+
+	var list ListResult[LogModel]
+	err = json.Unmarshal(respBody, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+func (c *Client) GetLog(ctx context.Context, id string, opts ...RequestOption) (*LogModel, error) {
+	respBody, err := c.doRequest(ctx, "GET", "/api/logs/"+id, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get log %s: %w", id, err)
+	}
+
+	var log LogModel
+	err = json.Unmarshal(respBody, &log)
+	if err != nil {
+		return nil, err
+	}
+
+	return &log, nil
+}
+
+// LogsStats returns hourly log counts, optionally restricted by filter.
+func (c *Client) LogsStats(ctx context.Context, filter string, opts ...RequestOption) ([]LogsStat, error) {
+	opts = append(opts[:len(opts):len(opts)], WithFilter(filter))
+	respBody, err := c.doRequest(ctx, "GET", "/api/logs/stats", nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logs stats: %w", err)
+	}
+
+	var stats []LogsStat
+	err = json.Unmarshal(respBody, &stats)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
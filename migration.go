@@ -0,0 +1,780 @@
+package gopocketbaseclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MigrationConfig configures MigrateCollection.
+type MigrationConfig struct {
+	Source      *Client
+	Destination *Client
+	Collection  string
+	// DestinationCollection defaults to Collection.
+	DestinationCollection string
+	// PreserveIDs creates destination records with their source IDs so
+	// relation fields stay valid. Records whose ID already exists in the
+	// destination are skipped.
+	PreserveIDs bool
+	// KeyField names the destination field that identifies a record on both
+	// instances, e.g. a unique "email" or "slug". Records whose key already
+	// exists in the destination, or appeared earlier in the source, are
+	// skipped. It defaults to "id" with PreserveIDs; otherwise no duplicate
+	// check is done. The value is taken after Transform.
+	KeyField string
+	// IndexDestination loads every destination key once before migrating,
+	// instead of one lookup per record. It trades memory for round-trips.
+	IndexDestination bool
+	// IDMap, when set, receives the destination ID of every created record,
+	// and of every record skipped because its key exists, and is used to
+	// rewrite the fields listed in Relations. Share one IDMap across the
+	// collections of a MigrationPlan.
+	IDMap *IDMap
+	// Relations maps relation field names to the collection they point to,
+	// as found in MigrationPlan.Relations. Relations to records of the same
+	// collection that have not been migrated yet are left empty on create
+	// and set in a second pass after every record has been read.
+	Relations map[string]string
+	// CheckSchema compares the source and destination schemas before any
+	// record is migrated and reports the result in MigrationResult.SchemaDiff.
+	// The migration stops with ErrSchemaMismatch on type mismatches, and on
+	// missing fields unless CreateSchema adds them.
+	CheckSchema bool
+	// CreateSchema creates the destination collection from the source
+	// schema when it is missing, and adds fields it lacks otherwise.
+	CreateSchema bool
+	// SchemaOptions are passed to the collections API on both instances,
+	// which requires superuser credentials, e.g. WithAdminAuth().
+	SchemaOptions []RequestOption
+	// Files downloads the attachments of file fields from the source and
+	// uploads them to the destination record. Protected files are fetched
+	// with a file token. Reading the schema requires SchemaOptions.
+	Files bool
+	// MaxFileSize fails records with a larger attachment; 0 means no limit.
+	// Attachments are buffered in memory while a record is migrated.
+	MaxFileSize int64
+	// FileConcurrency limits parallel downloads per record; defaults to 4.
+	FileConcurrency int
+	// Filter limits the migration to matching source records, e.g.
+	// "created >= '2024-01-01'". See BuildFilter for binding values.
+	Filter string
+	// Fields limits the migrated fields; id is always included.
+	Fields []string
+	// Transform, when set, receives each record without its system fields
+	// and returns the data to write; returning false skips the record. It
+	// runs before any destination lookup.
+	Transform func(record map[string]interface{}) (map[string]interface{}, bool)
+	// Checkpoints, when set, records the last processed source ID so an
+	// interrupted migration resumes after it. Records are then read in ID
+	// order. Failed records are not retried on resume; they are reported in
+	// the result of the run that attempted them. Records interrupted because
+	// ctx was done are retried. An IDMap is not persisted,
+	// so resuming a migration that remaps relations needs the same IDMap.
+	Checkpoints CheckpointStore
+	// Concurrency is the number of records of a batch migrated in parallel;
+	// defaults to 1. Transform and OnProgress may then be called from
+	// several goroutines, although OnProgress calls never overlap.
+	Concurrency int
+	// OnProgress, when set, is called after every processed record.
+	OnProgress func(MigrationProgress)
+	// RollbackOnError deletes the records created by this run when it
+	// returns an error or any record failed; see MigrationResult.Rollback.
+	RollbackOnError bool
+	// DryRun performs every read, existence check and schema comparison but
+	// no writes. The result then describes what a real run would do, with
+	// one entry per record in MigrationResult.Records.
+	DryRun bool
+}
+
+// MigrationProgress is passed to MigrationConfig.OnProgress. Expected is the
+// number of records the run will process, read from the source before it
+// starts; ETA is estimated from the rate so far and is zero until known.
+type MigrationProgress struct {
+	Collection string
+	Processed  int
+	Expected   int
+	Created    int
+	Skipped    int
+	Failed     int
+	Batch      int
+	Elapsed    time.Duration
+	ETA        time.Duration
+}
+
+// MigrationAction is what happened, or with DryRun would happen, to a
+// source record.
+type MigrationAction string
+
+const (
+	MigrationCreate MigrationAction = "create"
+	MigrationSkip   MigrationAction = "skip"
+	MigrationFail   MigrationAction = "fail"
+)
+
+// RecordOutcome describes the migration of a single source record.
+type RecordOutcome struct {
+	SourceID string
+	Action   MigrationAction
+	Err      error
+}
+
+// MigrationResult reports what MigrateCollection did.
+type MigrationResult struct {
+	Collection string
+	Total      int
+	Created    int
+	Skipped    int
+	Failed     int
+	Errors     []error
+	// SchemaCreated and FieldsAdded describe the changes made by
+	// CreateSchema.
+	SchemaCreated bool
+	FieldsAdded   []string
+	// SchemaDiff is the comparison made by CheckSchema, before CreateSchema
+	// changed anything.
+	SchemaDiff *SchemaDiff
+	DryRun     bool
+	// Records is only filled in for dry runs.
+	Records    []RecordOutcome
+	RolledBack bool
+
+	dest           *Client
+	destCollection string
+	createdIDs     []string
+	checkpoints    CheckpointStore
+	checkpointKey  string
+	resumedFrom    string
+}
+
+// Rollback deletes the destination records created by the migration, newest
+// first, and rewinds the checkpoint to where the run started. Records that
+// are already gone are ignored. Fields added by CreateSchema are kept.
+func (r *MigrationResult) Rollback(ctx context.Context) error {
+	var errs []error
+	var remaining []string
+	for i := len(r.createdIDs) - 1; i >= 0; i-- {
+		id := r.createdIDs[i]
+		err := r.dest.DeleteRecord(ctx, r.destCollection, id)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			errs = append(errs, fmt.Errorf("record %s: %w", id, err))
+			remaining = append([]string{id}, remaining...)
+		}
+	}
+	r.createdIDs = remaining
+	if len(errs) > 0 {
+		return fmt.Errorf("migration: rollback of %s incomplete: %w", r.Collection, errors.Join(errs...))
+	}
+
+	if r.checkpoints != nil {
+		if err := r.checkpoints.Save(ctx, r.checkpointKey, r.resumedFrom); err != nil {
+			return fmt.Errorf("migration: failed to rewind checkpoint %s: %w", r.checkpointKey, err)
+		}
+	}
+	r.RolledBack = true
+	return nil
+}
+
+func (r *MigrationResult) progress(expected int, elapsed time.Duration) MigrationProgress {
+	p := MigrationProgress{
+		Collection: r.Collection,
+		Processed:  r.Total,
+		Expected:   expected,
+		Created:    r.Created,
+		Skipped:    r.Skipped,
+		Failed:     r.Failed,
+		Batch:      (r.Total-1)/DefaultBatchSize + 1,
+		Elapsed:    elapsed,
+	}
+	if r.Total > 0 && expected > r.Total {
+		p.ETA = elapsed / time.Duration(r.Total) * time.Duration(expected-r.Total)
+	}
+	return p
+}
+
+// MigrateCollection copies every record of a collection from the source to
+// the destination instance. Per-record failures are collected in the result;
+// the returned error is only set when the migration could not run.
+func MigrateCollection(ctx context.Context, cfg MigrationConfig) (*MigrationResult, error) {
+	result, err := migrateCollection(ctx, cfg)
+	if cfg.RollbackOnError && !cfg.DryRun && result != nil && (err != nil || result.Failed > 0) {
+		// Roll back even when ctx was the reason the run stopped.
+		if rollbackErr := result.Rollback(context.WithoutCancel(ctx)); rollbackErr != nil {
+			err = errors.Join(err, rollbackErr)
+		}
+	}
+	return result, err
+}
+
+func migrateCollection(ctx context.Context, cfg MigrationConfig) (*MigrationResult, error) {
+	if cfg.Source == nil || cfg.Destination == nil {
+		return nil, errors.New("migration: source and destination clients are required")
+	}
+	destCollection := cfg.DestinationCollection
+	if destCollection == "" {
+		destCollection = cfg.Collection
+	}
+
+	m := &migration{cfg: cfg, destCollection: destCollection, keyField: cfg.KeyField, claimed: make(map[string]string), start: cfg.Source.now()}
+	if m.keyField == "" && cfg.PreserveIDs {
+		m.keyField = "id"
+	}
+	result := &MigrationResult{
+		Collection:     cfg.Collection,
+		DryRun:         cfg.DryRun,
+		dest:           cfg.Destination,
+		destCollection: destCollection,
+	}
+	if cfg.CreateSchema || cfg.CheckSchema || cfg.Files {
+		source, err := cfg.Source.GetCollection(ctx, cfg.Collection, cfg.SchemaOptions...)
+		if err != nil {
+			return result, fmt.Errorf("migration: %w", err)
+		}
+		var dest *CollectionModel
+		if cfg.CreateSchema || cfg.CheckSchema {
+			dest, err = cfg.Destination.lookupCollection(ctx, destCollection, cfg.SchemaOptions...)
+			if err != nil {
+				return result, fmt.Errorf("migration: %w", err)
+			}
+		}
+		if cfg.CheckSchema {
+			diff := diffSchemas(source, dest)
+			result.SchemaDiff = diff
+			if len(diff.TypeMismatches) > 0 || (!cfg.CreateSchema && len(diff.MissingFields) > 0) {
+				return result, fmt.Errorf("migration: %s: %w", diff, ErrSchemaMismatch)
+			}
+		}
+		if cfg.CreateSchema {
+			if err := m.ensureSchema(ctx, source, dest, result); err != nil {
+				return result, err
+			}
+		}
+		if cfg.Files {
+			m.fileFields = make(map[string]bool)
+			for _, field := range source.SchemaFields() {
+				if field.Type == "file" {
+					protected, _ := field.Option("protected")
+					m.fileFields[field.Name] = protected == true
+				}
+			}
+		}
+	}
+
+	if cfg.IndexDestination && m.keyField != "" {
+		if err := m.loadIndex(ctx); err != nil {
+			return result, fmt.Errorf("migration: failed to index %s: %w", destCollection, err)
+		}
+	}
+
+	var opts []RequestOption
+	if len(cfg.Fields) > 0 {
+		opts = append(opts, WithFields(append([]string{"id"}, cfg.Fields...)...))
+	}
+
+	checkpointKey := "migrate:" + cfg.Collection + ":" + destCollection
+	checkpoint, err := loadCheckpoint(ctx, cfg.Checkpoints, checkpointKey)
+	if err != nil {
+		return result, err
+	}
+	result.checkpoints, result.checkpointKey, result.resumedFrom = cfg.Checkpoints, checkpointKey, checkpoint
+	filter := cfg.Filter
+	if cfg.Checkpoints != nil {
+		opts = append(opts, WithSort("id"))
+		if checkpoint != "" {
+			after := BuildFilter("id > {:id}", map[string]interface{}{"id": checkpoint})
+			if filter != "" {
+				after = "(" + filter + ") && " + after
+			}
+			filter = after
+		}
+	}
+	if filter != "" {
+		opts = append(opts, WithFilter(filter))
+	}
+	saved := checkpoint
+	save := func() error {
+		if cfg.DryRun {
+			return nil
+		}
+		// Keep the progress made even when ctx stopped the run.
+		err := saveCheckpoint(context.WithoutCancel(ctx), cfg.Checkpoints, checkpointKey, saved, checkpoint)
+		saved = checkpoint
+		return err
+	}
+
+	if cfg.OnProgress != nil {
+		list, err := cfg.Source.GetList(ctx, cfg.Collection, 1, 1, append(opts[:len(opts):len(opts)], WithFields("id"))...)
+		if err != nil {
+			return result, fmt.Errorf("migration: failed to count %s: %w", cfg.Collection, err)
+		}
+		m.expected = list.TotalItems
+	}
+
+	batch := make([]map[string]interface{}, 0, DefaultBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if n := m.migrateBatch(ctx, batch, result); n > 0 {
+			checkpoint, _ = batch[n-1]["id"].(string)
+		}
+		batch = batch[:0]
+		return save()
+	}
+
+	var iterErr, saveErr error
+	cfg.Source.Iterate(ctx, cfg.Collection, opts...)(func(item json.RawMessage, err error) bool {
+		if err != nil {
+			iterErr = err
+			return false
+		}
+
+		var record map[string]interface{}
+		if err := json.Unmarshal(item, &record); err != nil {
+			iterErr = err
+			return false
+		}
+
+		batch = append(batch, record)
+		if len(batch) == DefaultBatchSize {
+			saveErr = flush()
+		}
+		return saveErr == nil
+	})
+	if saveErr == nil {
+		saveErr = flush()
+	}
+	m.linkSelfRelations(ctx, result)
+	if iterErr != nil {
+		iterErr = fmt.Errorf("migration: failed to read %s: %w", cfg.Collection, iterErr)
+		if saveErr != nil {
+			iterErr = errors.Join(iterErr, fmt.Errorf("migration: %w", saveErr))
+		}
+		return result, iterErr
+	}
+	if saveErr != nil {
+		return result, fmt.Errorf("migration: %w", saveErr)
+	}
+	if err := ctx.Err(); err != nil {
+		return result, fmt.Errorf("migration: %w", err)
+	}
+
+	return result, nil
+}
+
+// migration holds the state shared by the records of one MigrateCollection
+// run.
+type migration struct {
+	cfg            MigrationConfig
+	destCollection string
+	// fileFields maps file field names to whether the field is protected.
+	fileFields map[string]bool
+	keyField   string
+	expected   int
+	start      time.Time
+
+	// mu guards claimed, the result and OnProgress calls.
+	mu sync.Mutex
+	// claimed maps the keys of the records created or being created by
+	// this run, and every destination key when indexed is set, to their
+	// destination ID; it is empty while the record is being created.
+	claimed map[string]string
+	indexed bool
+	// pending holds the self-relations left out of created records.
+	pending []pendingRelations
+}
+
+// pendingRelations are the self-relation fields of a created record, with
+// their source IDs, that referenced records not migrated yet.
+type pendingRelations struct {
+	sourceID, destID string
+	fields           map[string]interface{}
+}
+
+// migrateBatch migrates records with up to Concurrency workers. It returns
+// how many leading records of batch were processed before ctx was done;
+// the others are retried when the migration resumes.
+func (m *migration) migrateBatch(ctx context.Context, batch []map[string]interface{}, result *MigrationResult) int {
+	completed := make([]bool, len(batch))
+	forEachConcurrent(m.cfg.Concurrency, len(batch), func(i int) {
+		if ctx.Err() != nil {
+			return
+		}
+		completed[i] = m.migrateRecord(ctx, batch[i], result)
+	})
+
+	n := 0
+	for n < len(completed) && completed[n] {
+		n++
+	}
+	return n
+}
+
+// finish records the outcome of one source record.
+func (m *migration) finish(result *MigrationResult, id string, action MigrationAction, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result.Total++
+	switch action {
+	case MigrationCreate:
+		result.Created++
+	case MigrationSkip:
+		result.Skipped++
+	case MigrationFail:
+		err = fmt.Errorf("record %s: %w", id, err)
+		result.Failed++
+		result.Errors = append(result.Errors, err)
+	}
+	if result.DryRun {
+		result.Records = append(result.Records, RecordOutcome{SourceID: id, Action: action, Err: err})
+	}
+
+	if m.cfg.OnProgress != nil {
+		m.cfg.OnProgress(result.progress(m.expected, m.cfg.Source.now().Sub(m.start)))
+	}
+}
+
+// loadIndex reads every destination key. A missing destination collection
+// yields an empty index.
+func (m *migration) loadIndex(ctx context.Context) error {
+	m.indexed = true
+	err := forEachRecord(ctx, m.cfg.Destination, m.destCollection, []RequestOption{WithFields("id", m.keyField)}, func(record map[string]interface{}) {
+		if value, ok := record[m.keyField]; ok && value != nil {
+			m.claimed[fmt.Sprint(value)], _ = record["id"].(string)
+		}
+	})
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// forget releases a key claimed by exists after the record failed.
+func (m *migration) forget(key interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if key != nil {
+		delete(m.claimed, fmt.Sprint(key))
+	}
+}
+
+// exists reports whether a record with the same key as data is already in
+// the destination, and its destination ID when known. A missing key is
+// claimed at once, before the lookup, so that concurrent duplicates in the
+// source are skipped instead of racing to be created.
+func (m *migration) exists(ctx context.Context, data map[string]interface{}) (string, bool, error) {
+	value, ok := data[m.keyField]
+	if m.keyField == "" || !ok || value == nil {
+		return "", false, nil
+	}
+	key := fmt.Sprint(value)
+	m.mu.Lock()
+	if destID, ok := m.claimed[key]; ok {
+		m.mu.Unlock()
+		return destID, true, nil
+	}
+	m.claimed[key] = ""
+	indexed := m.indexed
+	m.mu.Unlock()
+	if indexed {
+		return "", false, nil
+	}
+
+	list, err := m.cfg.Destination.GetList(ctx, m.destCollection, 1, 1,
+		WithFilter(Eq(m.keyField, value).String()), WithFields("id"), WithSkipTotal())
+	if errors.Is(err, ErrNotFound) {
+		return "", false, nil
+	}
+	if err != nil {
+		m.forget(value)
+		return "", false, err
+	}
+
+	var items []struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(list.Items, &items); err != nil {
+		m.forget(value)
+		return "", false, err
+	}
+	if len(items) == 0 {
+		return "", false, nil
+	}
+	m.mu.Lock()
+	m.claimed[key] = items[0].ID
+	m.mu.Unlock()
+	return items[0].ID, true, nil
+}
+
+// migrateRecord migrates one source record. It returns false when the
+// record failed because ctx was done.
+func (m *migration) migrateRecord(ctx context.Context, record map[string]interface{}, result *MigrationResult) bool {
+	cfg, destCollection := m.cfg, m.destCollection
+	id, _ := record["id"].(string)
+	data := stripSystemFields(record)
+	if cfg.Transform != nil {
+		var ok bool
+		if data, ok = cfg.Transform(data); !ok {
+			m.finish(result, id, MigrationSkip, nil)
+			return true
+		}
+	}
+
+	existingID, exists, err := m.exists(ctx, data)
+	if err != nil {
+		m.finish(result, id, MigrationFail, err)
+		return ctx.Err() == nil
+	}
+	if exists {
+		// Relations in later collections must point at the existing record.
+		if cfg.IDMap != nil && existingID != "" {
+			cfg.IDMap.Set(cfg.Collection, id, existingID)
+		}
+		m.finish(result, id, MigrationSkip, nil)
+		return true
+	}
+	key := data[m.keyField]
+
+	var pending map[string]interface{}
+	if cfg.IDMap != nil && !cfg.DryRun {
+		pending = m.deferSelfRelations(data)
+	}
+	if !cfg.PreserveIDs {
+		delete(data, "id")
+		if cfg.IDMap != nil {
+			remapRelations(data, cfg.Relations, cfg.IDMap)
+		}
+	}
+
+	if cfg.DryRun {
+		m.finish(result, id, MigrationCreate, nil)
+		return true
+	}
+
+	var created map[string]interface{}
+	var files map[string][]FileUpload
+	files, err = m.downloadFiles(ctx, id, data)
+	if err == nil && len(files) > 0 {
+		created, err = cfg.Destination.CreateRecordWithFiles(ctx, destCollection, data, files)
+	} else if err == nil {
+		var out *map[string]interface{}
+		out, err = Collection[map[string]interface{}](cfg.Destination, destCollection).Create(ctx, data)
+		if out != nil {
+			created = *out
+		}
+	}
+	if err != nil {
+		m.forget(key)
+		m.finish(result, id, MigrationFail, err)
+		return ctx.Err() == nil
+	}
+
+	destID, _ := created["id"].(string)
+	if cfg.IDMap != nil {
+		cfg.IDMap.Set(cfg.Collection, id, destID)
+	}
+	m.mu.Lock()
+	if key != nil {
+		m.claimed[fmt.Sprint(key)] = destID
+	}
+	result.createdIDs = append(result.createdIDs, destID)
+	if len(pending) > 0 {
+		m.pending = append(m.pending, pendingRelations{sourceID: id, destID: destID, fields: pending})
+	}
+	m.mu.Unlock()
+	m.finish(result, id, MigrationCreate, nil)
+	return true
+}
+
+// deferSelfRelations removes from data the relation fields pointing into
+// the migrated collection that reference a record without a destination
+// ID yet, which the destination would reject, and returns their values.
+func (m *migration) deferSelfRelations(data map[string]interface{}) map[string]interface{} {
+	var pending map[string]interface{}
+	for field, target := range m.cfg.Relations {
+		if target != m.cfg.Collection {
+			continue
+		}
+		var refs []interface{}
+		switch v := data[field].(type) {
+		case string:
+			refs = []interface{}{v}
+		case []interface{}:
+			refs = v
+		}
+		for _, ref := range refs {
+			s, ok := ref.(string)
+			if !ok || s == "" {
+				continue
+			}
+			if _, ok := m.cfg.IDMap.Lookup(target, s); ok {
+				continue
+			}
+			if pending == nil {
+				pending = make(map[string]interface{})
+			}
+			pending[field] = data[field]
+			delete(data, field)
+			break
+		}
+	}
+	return pending
+}
+
+// linkSelfRelations sets the relations left out by deferSelfRelations now
+// that every record of the collection has a destination ID.
+func (m *migration) linkSelfRelations(ctx context.Context, result *MigrationResult) {
+	for _, p := range m.pending {
+		if !m.cfg.PreserveIDs {
+			remapRelations(p.fields, m.cfg.Relations, m.cfg.IDMap)
+		}
+		if err := m.cfg.Destination.UpdateRecord(ctx, m.destCollection, p.destID, p.fields); err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Errorf("record %s: failed to link relations: %w", p.sourceID, err))
+		}
+	}
+	m.pending = nil
+}
+
+// ensureSchema makes the destination collection match the source schema.
+// Existing destination fields are never modified or removed; only missing
+// fields are added, without their source IDs. dest is nil when the
+// destination collection does not exist.
+func (m *migration) ensureSchema(ctx context.Context, source, dest *CollectionModel, result *MigrationResult) error {
+	cfg, destCollection := m.cfg, m.destCollection
+	if dest == nil {
+		model := *source
+		model.Created, model.Updated = "", ""
+		if destCollection != source.Name {
+			model.ID, model.Name = "", destCollection
+		}
+		result.SchemaCreated = true
+		if cfg.DryRun {
+			return nil
+		}
+		if _, err := cfg.Destination.CreateCollection(ctx, model, cfg.SchemaOptions...); err != nil {
+			return fmt.Errorf("migration: %w", err)
+		}
+		return nil
+	}
+
+	existing := make(map[string]bool)
+	for _, field := range dest.SchemaFields() {
+		existing[field.Name] = true
+	}
+
+	fields := dest.SchemaFields()
+	for _, field := range source.SchemaFields() {
+		if existing[field.Name] {
+			continue
+		}
+		field.ID = ""
+		fields = append(fields, field)
+		result.FieldsAdded = append(result.FieldsAdded, field.Name)
+	}
+	if len(result.FieldsAdded) == 0 || cfg.DryRun {
+		return nil
+	}
+
+	update := *dest
+	if len(dest.Fields) > 0 || len(source.Fields) > 0 {
+		update.Fields, update.Schema = fields, nil
+	} else {
+		update.Schema = fields
+	}
+	if _, err := cfg.Destination.UpdateCollection(ctx, dest.ID, update, cfg.SchemaOptions...); err != nil {
+		return fmt.Errorf("migration: %w", err)
+	}
+	return nil
+}
+
+// downloadFiles removes the file fields from data and downloads the files
+// they name from the source record.
+func (m *migration) downloadFiles(ctx context.Context, recordID string, data map[string]interface{}) (map[string][]FileUpload, error) {
+	type download struct {
+		field, filename string
+		protected       bool
+		buf             bytes.Buffer
+		err             error
+	}
+
+	var downloads []*download
+	needToken := false
+	for field, protected := range m.fileFields {
+		var filenames []string
+		switch v := data[field].(type) {
+		case string:
+			if v != "" {
+				filenames = append(filenames, v)
+			}
+		case []interface{}:
+			for _, item := range v {
+				if s, ok := item.(string); ok && s != "" {
+					filenames = append(filenames, s)
+				}
+			}
+		}
+		delete(data, field)
+
+		for _, filename := range filenames {
+			downloads = append(downloads, &download{field: field, filename: filename, protected: protected})
+		}
+		needToken = needToken || (protected && len(filenames) > 0)
+	}
+	if len(downloads) == 0 {
+		return nil, nil
+	}
+
+	var token string
+	if needToken {
+		var err error
+		token, err = m.cfg.Source.FileToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	concurrency := m.cfg.FileConcurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	forEachConcurrent(concurrency, len(downloads), func(i int) {
+		d := downloads[i]
+		var opts []RequestOption
+		if d.protected {
+			opts = append(opts, WithQueryParam("token", token))
+		}
+		w := &limitedWriter{w: &d.buf, limit: m.cfg.MaxFileSize}
+		d.err = m.cfg.Source.DownloadFile(ctx, m.cfg.Collection, recordID, d.filename, w, opts...)
+	})
+
+	files := make(map[string][]FileUpload)
+	for _, d := range downloads {
+		if d.err != nil {
+			return nil, d.err
+		}
+		files[d.field] = append(files[d.field], FileUpload{Filename: d.filename, Reader: &d.buf})
+	}
+	return files, nil
+}
+
+// limitedWriter fails once more than limit bytes are written. A zero limit
+// means no limit.
+type limitedWriter struct {
+	w       *bytes.Buffer
+	limit   int64
+	written int64
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	l.written += int64(len(p))
+	if l.limit > 0 && l.written > l.limit {
+		return 0, fmt.Errorf("file exceeds MaxFileSize of %d bytes", l.limit)
+	}
+	return l.w.Write(p)
+}
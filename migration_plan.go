@@ -0,0 +1,324 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// IDMap records which destination ID each migrated source record received,
+// per collection. It is safe for concurrent use.
+type IDMap struct {
+	mu  sync.Mutex
+	ids map[string]map[string]string
+}
+
+func NewIDMap() *IDMap {
+	return &IDMap{ids: make(map[string]map[string]string)}
+}
+
+func (m *IDMap) Set(collection, sourceID, destID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.ids[collection] == nil {
+		m.ids[collection] = make(map[string]string)
+	}
+	m.ids[collection][sourceID] = destID
+}
+
+func (m *IDMap) Lookup(collection, sourceID string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id, ok := m.ids[collection][sourceID]
+	return id, ok
+}
+
+// MigrationPlan orders collections so that every collection is migrated
+// after the collections its relation fields point to.
+type MigrationPlan struct {
+	Collections []string
+	// Relations maps collection name to relation field name to the name of
+	// the collection the field points to.
+	Relations map[string]map[string]string
+}
+
+// PlanMigration reads the source schema and orders collections by relation
+// dependency. Self-relations are ignored for ordering; other cycles are an
+// error. Reading the schema requires superuser credentials, e.g. by passing
+// WithAdminAuth().
+func PlanMigration(ctx context.Context, source *Client, collections []string, opts ...RequestOption) (*MigrationPlan, error) {
+	models, err := source.ListCollections(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return planFromModels(models, collections)
+}
+
+func planFromModels(models []CollectionModel, collections []string) (*MigrationPlan, error) {
+	names := make(map[string]string, len(models))
+	for _, m := range models {
+		names[m.ID] = m.Name
+	}
+
+	plan := &MigrationPlan{Relations: make(map[string]map[string]string)}
+	for _, m := range models {
+		for _, field := range m.SchemaFields() {
+			if field.Type != "relation" {
+				continue
+			}
+			target, _ := field.Option("collectionId")
+			targetID, _ := target.(string)
+			targetName, ok := names[targetID]
+			if !ok {
+				continue
+			}
+			if plan.Relations[m.Name] == nil {
+				plan.Relations[m.Name] = make(map[string]string)
+			}
+			plan.Relations[m.Name][field.Name] = targetName
+		}
+	}
+
+	ordered, err := sortByDependency(collections, plan.Relations)
+	if err != nil {
+		return nil, err
+	}
+	plan.Collections = ordered
+	return plan, nil
+}
+
+// sortByDependency topologically sorts collections, keeping the input order
+// among collections that don't depend on each other. Relations to
+// collections outside the list are ignored.
+func sortByDependency(collections []string, relations map[string]map[string]string) ([]string, error) {
+	included := make(map[string]bool, len(collections))
+	for _, name := range collections {
+		included[name] = true
+	}
+
+	pending := make(map[string]map[string]bool, len(collections))
+	for _, name := range collections {
+		deps := make(map[string]bool)
+		for _, target := range relations[name] {
+			if target != name && included[target] {
+				deps[target] = true
+			}
+		}
+		pending[name] = deps
+	}
+
+	ordered := make([]string, 0, len(collections))
+	for len(ordered) < len(collections) {
+		progressed := false
+		for _, name := range collections {
+			deps, ok := pending[name]
+			if !ok || len(deps) > 0 {
+				continue
+			}
+			ordered = append(ordered, name)
+			delete(pending, name)
+			for _, other := range pending {
+				delete(other, name)
+			}
+			progressed = true
+		}
+		if !progressed {
+			var cycle []string
+			for name := range pending {
+				cycle = append(cycle, name)
+			}
+			sort.Strings(cycle)
+			return nil, fmt.Errorf("migration: relation cycle between %s", strings.Join(cycle, ", "))
+		}
+	}
+	return ordered, nil
+}
+
+// remapRelations rewrites relation fields of data to the destination IDs
+// recorded in ids. IDs without a mapping are left unchanged.
+func remapRelations(data map[string]interface{}, relations map[string]string, ids *IDMap) {
+	for field, target := range relations {
+		switch v := data[field].(type) {
+		case string:
+			if id, ok := ids.Lookup(target, v); ok {
+				data[field] = id
+			}
+		case []interface{}:
+			remapped := make([]interface{}, len(v))
+			for i, item := range v {
+				remapped[i] = item
+				if s, ok := item.(string); ok {
+					if id, ok := ids.Lookup(target, s); ok {
+						remapped[i] = id
+					}
+				}
+			}
+			data[field] = remapped
+		}
+	}
+}
+
+// MultiMigrationConfig configures MigrateCollections.
+type MultiMigrationConfig struct {
+	Source      *Client
+	Destination *Client
+	// Collections to migrate; empty means every non-system, non-view
+	// collection of the source.
+	Collections []string
+	PreserveIDs bool
+	// CreateSchema creates or extends each destination collection before
+	// its records are migrated; see MigrationConfig.CreateSchema.
+	CreateSchema bool
+	// CheckSchema compares every collection before migrating it; see
+	// MigrationConfig.CheckSchema.
+	CheckSchema bool
+	// SchemaOptions are passed to the collections API, which requires
+	// superuser credentials, e.g. WithAdminAuth().
+	SchemaOptions []RequestOption
+	// Files, MaxFileSize and FileConcurrency migrate attachments; see
+	// MigrationConfig.Files.
+	Files           bool
+	MaxFileSize     int64
+	FileConcurrency int
+	// Filters and Fields map collection names to the MigrationConfig.Filter
+	// and MigrationConfig.Fields of that collection.
+	Filters map[string]string
+	Fields  map[string][]string
+	// Transform, when set, is called like MigrationConfig.Transform with
+	// the name of the record's collection.
+	Transform func(collection string, record map[string]interface{}) (map[string]interface{}, bool)
+	// Checkpoints makes every collection resumable; see
+	// MigrationConfig.Checkpoints. Checkpoint keys include the collection.
+	Checkpoints CheckpointStore
+	// KeyFields maps collection names to their MigrationConfig.KeyField.
+	KeyFields map[string]string
+	// IndexDestination is passed to every collection; see
+	// MigrationConfig.IndexDestination.
+	IndexDestination bool
+	// Concurrency is passed to every collection; see
+	// MigrationConfig.Concurrency.
+	Concurrency int
+	// DryRun is passed to every collection; see MigrationConfig.DryRun.
+	DryRun bool
+	// OnProgress is called after every record; Collection tells the
+	// collections apart.
+	OnProgress func(MigrationProgress)
+	// RollbackOnError rolls back every collection of the run, in reverse
+	// order, when any collection returns an error or has failed records.
+	RollbackOnError bool
+}
+
+// MultiMigrationResult aggregates the per-collection results of
+// MigrateCollections, in migration order.
+type MultiMigrationResult struct {
+	Collections []*MigrationResult
+	Total       int
+	Created     int
+	Skipped     int
+	Failed      int
+}
+
+// Rollback rolls back every collection, in reverse migration order. It stops
+// at the first collection that cannot be rolled back completely.
+func (r *MultiMigrationResult) Rollback(ctx context.Context) error {
+	for i := len(r.Collections) - 1; i >= 0; i-- {
+		if r.Collections[i].RolledBack {
+			continue
+		}
+		if err := r.Collections[i].Rollback(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MultiMigrationResult) add(result *MigrationResult) {
+	r.Collections = append(r.Collections, result)
+	r.Total += result.Total
+	r.Created += result.Created
+	r.Skipped += result.Skipped
+	r.Failed += result.Failed
+}
+
+// MigrateCollections migrates several collections in one run, ordered by
+// relation dependency. Unless PreserveIDs is set, relation fields are
+// rewritten to the IDs created in the destination. It stops at the first
+// collection that cannot be read and returns the results so far.
+func MigrateCollections(ctx context.Context, cfg MultiMigrationConfig) (*MultiMigrationResult, error) {
+	if cfg.Source == nil || cfg.Destination == nil {
+		return nil, errors.New("migration: source and destination clients are required")
+	}
+
+	models, err := cfg.Source.ListCollections(ctx, cfg.SchemaOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	collections := cfg.Collections
+	if len(collections) == 0 {
+		for _, m := range models {
+			if !m.System && m.Type != "view" {
+				collections = append(collections, m.Name)
+			}
+		}
+	}
+
+	plan, err := planFromModels(models, collections)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := migrateCollections(ctx, cfg, plan)
+	if cfg.RollbackOnError && !cfg.DryRun && (err != nil || report.Failed > 0) {
+		if rollbackErr := report.Rollback(context.WithoutCancel(ctx)); rollbackErr != nil {
+			err = errors.Join(err, rollbackErr)
+		}
+	}
+	return report, err
+}
+
+func migrateCollections(ctx context.Context, cfg MultiMigrationConfig, plan *MigrationPlan) (*MultiMigrationResult, error) {
+	ids := NewIDMap()
+	report := &MultiMigrationResult{}
+	for _, name := range plan.Collections {
+		var transform func(map[string]interface{}) (map[string]interface{}, bool)
+		if cfg.Transform != nil {
+			transform = func(record map[string]interface{}) (map[string]interface{}, bool) {
+				return cfg.Transform(name, record)
+			}
+		}
+		result, err := MigrateCollection(ctx, MigrationConfig{
+			Source:           cfg.Source,
+			Destination:      cfg.Destination,
+			Collection:       name,
+			PreserveIDs:      cfg.PreserveIDs,
+			IDMap:            ids,
+			Relations:        plan.Relations[name],
+			CreateSchema:     cfg.CreateSchema,
+			CheckSchema:      cfg.CheckSchema,
+			SchemaOptions:    cfg.SchemaOptions,
+			Files:            cfg.Files,
+			MaxFileSize:      cfg.MaxFileSize,
+			FileConcurrency:  cfg.FileConcurrency,
+			Filter:           cfg.Filters[name],
+			Fields:           cfg.Fields[name],
+			Transform:        transform,
+			Checkpoints:      cfg.Checkpoints,
+			KeyField:         cfg.KeyFields[name],
+			IndexDestination: cfg.IndexDestination,
+			Concurrency:      cfg.Concurrency,
+			DryRun:           cfg.DryRun,
+			OnProgress:       cfg.OnProgress,
+		})
+		if result != nil {
+			report.add(result)
+		}
+		if err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
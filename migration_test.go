@@ -0,0 +1,141 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ashkenazi1/gopocketbaseclient/pbtest"
+)
+
+func TestMigrateCollectionSelfRelations(t *testing.T) {
+	source, dest := pbtest.NewServer(), pbtest.NewServer()
+	defer source.Close()
+	defer dest.Close()
+	_, err := source.Insert("nodes",
+		map[string]interface{}{"id": "child0000000001", "name": "child", "parent": "root00000000001"},
+		map[string]interface{}{"id": "root00000000001", "name": "root", "parent": ""},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := NewIDMap()
+	result, err := MigrateCollection(context.Background(), MigrationConfig{
+		Source:      NewClient(source.URL, ""),
+		Destination: NewClient(dest.URL, ""),
+		Collection:  "nodes",
+		IDMap:       ids,
+		Relations:   map[string]string{"parent": "nodes"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Created != 2 || result.Failed != 0 {
+		t.Fatalf("created %d, failed %d: %v", result.Created, result.Failed, result.Errors)
+	}
+
+	rootID, _ := ids.Lookup("nodes", "root00000000001")
+	for _, record := range dest.Records("nodes") {
+		if record["name"] == "child" && record["parent"] != rootID {
+			t.Errorf("child parent = %v, want %s", record["parent"], rootID)
+		}
+	}
+}
+
+func TestMigrateCollectionCheckpointOnCancel(t *testing.T) {
+	source, dest := pbtest.NewServer(), pbtest.NewServer()
+	defer source.Close()
+	defer dest.Close()
+	for _, id := range []string{"a00000000000001", "b00000000000001", "c00000000000001", "d00000000000001"} {
+		if _, err := source.Insert("tasks", map[string]interface{}{"id": id, "title": id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	checkpoints := NewMemoryCheckpointStore()
+	_, err := MigrateCollection(ctx, MigrationConfig{
+		Source:      NewClient(source.URL, ""),
+		Destination: NewClient(dest.URL, ""),
+		Collection:  "tasks",
+		Checkpoints: checkpoints,
+		Transform: func(record map[string]interface{}) (map[string]interface{}, bool) {
+			if record["title"] == "c00000000000001" {
+				cancel()
+			}
+			return record, true
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+
+	got, err := checkpoints.Load(context.Background(), "migrate:tasks:tasks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "b00000000000001" {
+		t.Errorf("checkpoint = %q, want the last record migrated before the cancellation", got)
+	}
+}
+
+func TestMigrateCollectionConcurrentDuplicateKeys(t *testing.T) {
+	source, dest := pbtest.NewServer(), pbtest.NewServer()
+	defer source.Close()
+	defer dest.Close()
+	for i := 0; i < 20; i++ {
+		if _, err := source.Insert("users", map[string]interface{}{"email": "same@example.com"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := MigrateCollection(context.Background(), MigrationConfig{
+		Source:      NewClient(source.URL, ""),
+		Destination: NewClient(dest.URL, ""),
+		Collection:  "users",
+		KeyField:    "email",
+		Concurrency: 8,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(dest.Records("users")); n != 1 || result.Created != 1 || result.Skipped != 19 {
+		t.Errorf("destination has %d records, created %d, skipped %d; want 1, 1, 19", n, result.Created, result.Skipped)
+	}
+}
+
+func TestMigrateCollectionMapsSkippedRecords(t *testing.T) {
+	for _, indexed := range []bool{false, true} {
+		source, dest := pbtest.NewServer(), pbtest.NewServer()
+		defer source.Close()
+		defer dest.Close()
+		if _, err := source.Insert("users", map[string]interface{}{"id": "source000000001", "email": "a@example.com"}); err != nil {
+			t.Fatal(err)
+		}
+		existing, err := dest.Insert("users", map[string]interface{}{"email": "a@example.com"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids := NewIDMap()
+		result, err := MigrateCollection(context.Background(), MigrationConfig{
+			Source:           NewClient(source.URL, ""),
+			Destination:      NewClient(dest.URL, ""),
+			Collection:       "users",
+			KeyField:         "email",
+			IndexDestination: indexed,
+			IDMap:            ids,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Skipped != 1 {
+			t.Errorf("indexed=%v: skipped %d, want 1", indexed, result.Skipped)
+		}
+		if got, _ := ids.Lookup("users", "source000000001"); got != existing[0]["id"] {
+			t.Errorf("indexed=%v: IDMap has %q, want the existing record %v", indexed, got, existing[0]["id"])
+		}
+	}
+}
@@ -1,14 +1,41 @@
 package gopocketbaseclient
 
 import (
+	"bytes"
 	"encoding/json"
+	"log/slog"
 	"net/http"
+	"sync"
 )
 
 type Client struct {
 	BaseURL    string
 	HTTPClient *http.Client
 	Token      string
+	AdminToken string
+
+	mu            sync.Mutex
+	err           error
+	adminsPath    string
+	realtime      *realtime
+	clock         Clock
+	decodeOptions []DecodeOption
+	timeOutput    *TimeOutput
+	codec         Codec
+	cache         *responseCache
+	validators    *validatorCache
+	retryPolicy   *RetryPolicy
+	breaker       *circuitBreaker
+	onAuthChange  func(token string)
+	revokePath    string
+	logger        *slog.Logger
+	logBodies     bool
+	dump          func(RequestDump)
+	userAgent     string
+	header        http.Header
+	// ownedTransport is the *http.Transport the client owns and options may
+	// modify in place.
+	ownedTransport *http.Transport
 }
 
 type BaseRecord struct {
@@ -27,3 +54,17 @@ type Record struct {
 type JSONItems struct {
 	Items json.RawMessage `json:"items"`
 }
+
+// IsEmpty reports whether the response contained no records.
+func (j *JSONItems) IsEmpty() bool {
+	items := bytes.TrimSpace(j.Items)
+	return len(items) == 0 || string(items) == "[]" || string(items) == "null"
+}
+
+type PaginatedResponse struct {
+	Page       int             `json:"page"`
+	PerPage    int             `json:"perPage"`
+	TotalItems int             `json:"totalItems"`
+	TotalPages int             `json:"totalPages"`
+	Items      json.RawMessage `json:"items"`
+}
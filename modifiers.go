@@ -0,0 +1,31 @@
+package gopocketbaseclient
+
+// RelationAppend returns the change that appends ids to a multi-relation
+// field ("field+") instead of overwriting the whole array.
+func RelationAppend(field string, ids ...string) map[string]interface{} {
+	return map[string]interface{}{field + "+": ids}
+}
+
+// RelationPrepend returns the change that prepends ids to a multi-relation
+// field ("+field").
+func RelationPrepend(field string, ids ...string) map[string]interface{} {
+	return map[string]interface{}{"+" + field: ids}
+}
+
+// RelationRemove returns the change that removes ids from a multi-relation
+// field ("field-").
+func RelationRemove(field string, ids ...string) map[string]interface{} {
+	return map[string]interface{}{field + "-": ids}
+}
+
+// MergeChanges combines several change maps into a single record payload,
+// e.g. for UpdateRecord. Later maps win on conflicting keys.
+func MergeChanges(changes ...map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for _, change := range changes {
+		for k, v := range change {
+			merged[k] = v
+		}
+	}
+	return merged
+}
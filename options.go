@@ -0,0 +1,87 @@
+package gopocketbaseclient
+
+import "log/slog"
+
+// ClientOption configures a Client created by NewClient.
+type ClientOption func(*Client)
+
+// WithClock sets the Clock used for all time-dependent logic.
+func WithClock(clock Clock) ClientOption {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// WithDecodeOptions sets the options used when the client decodes record data.
+func WithDecodeOptions(opts ...DecodeOption) ClientOption {
+	return func(c *Client) {
+		c.decodeOptions = opts
+	}
+}
+
+// WithTimeOutput sets the datetime format of records this client encodes
+// from structs, overriding SetTimeOutput. Combine it with TimeLayouts and
+// TimeLocation in WithDecodeOptions to control parsing.
+func WithTimeOutput(out TimeOutput) ClientOption {
+	return func(c *Client) {
+		c.timeOutput = &out
+	}
+}
+
+// WithCodec replaces encoding/json for request bodies and record decoding,
+// the hot paths of large reads and bulk writes.
+func WithCodec(codec Codec) ClientOption {
+	return func(c *Client) {
+		c.codec = codec
+	}
+}
+
+// WithRetryPolicy enables automatic retries of failed requests.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = &policy
+	}
+}
+
+// WithOnAuthChange registers a callback fired whenever the client's token
+// changes through login, logout or account deletion. An empty token means the
+// session ended.
+func WithOnAuthChange(fn func(token string)) ClientOption {
+	return func(c *Client) {
+		c.onAuthChange = fn
+	}
+}
+
+// WithTokenRevocation sets a server route (e.g. a custom hook) that Logout
+// calls to invalidate the token server-side.
+func WithTokenRevocation(path string) ClientOption {
+	return func(c *Client) {
+		c.revokePath = path
+	}
+}
+
+// WithLogger logs every request (method, URL, status and latency) to logger
+// at debug level; failed requests are logged at warn level. Secret query
+// parameters, such as the token of protected file URLs, are redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithDebugBodies additionally logs request and response bodies. Password,
+// token and secret fields are redacted; the Authorization header is never
+// logged.
+func WithDebugBodies() ClientOption {
+	return func(c *Client) {
+		c.logBodies = true
+	}
+}
+
+// WithRequestDump calls fn with a curl rendering and the raw response of
+// every request; use WithDump to capture a single call instead.
+func WithRequestDump(fn func(RequestDump)) ClientOption {
+	return func(c *Client) {
+		c.dump = fn
+	}
+}
@@ -0,0 +1,338 @@
+package pbtest
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// matcher reports whether a record satisfies a filter.
+type matcher func(record map[string]interface{}) bool
+
+// parseFilter compiles the subset of the PocketBase filter syntax the fake
+// understands: comparisons (=, !=, >, >=, <, <=, ~, !~) between fields and
+// string, number, bool or null literals, combined with &&, || and
+// parentheses. An empty filter matches every record.
+func parseFilter(filter string) (matcher, error) {
+	p := &filterParser{input: filter}
+	p.skipSpace()
+	if p.done() {
+		return func(map[string]interface{}) bool { return true }, nil
+	}
+	m, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q at offset %d", p.input[p.pos:], p.pos)
+	}
+	return m, nil
+}
+
+type filterParser struct {
+	input string
+	pos   int
+}
+
+func (p *filterParser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *filterParser) skipSpace() {
+	for !p.done() && strings.ContainsRune(" \t\r\n", rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *filterParser) consume(token string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.input[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) parseOr() (matcher, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(r map[string]interface{}) bool { return l(r) || right(r) }
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (matcher, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume("&&") {
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(r map[string]interface{}) bool { return l(r) && right(r) }
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseTerm() (matcher, error) {
+	if p.consume("(") {
+		if p.consume(")") {
+			return func(map[string]interface{}) bool { return true }, nil
+		}
+		m, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, fmt.Errorf("missing ) at offset %d", p.pos)
+		}
+		return m, nil
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	op, err := p.parseOperator()
+	if err != nil {
+		return nil, err
+	}
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return func(r map[string]interface{}) bool {
+		return compare(left(r), op, right(r))
+	}, nil
+}
+
+var operators = []string{"!=", ">=", "<=", "!~", "=", ">", "<", "~"}
+
+func (p *filterParser) parseOperator() (string, error) {
+	for _, op := range operators {
+		if p.consume(op) {
+			return op, nil
+		}
+	}
+	return "", fmt.Errorf("expected an operator at offset %d", p.pos)
+}
+
+// operand returns the value of a field or literal for a record.
+type operand func(record map[string]interface{}) interface{}
+
+func (p *filterParser) parseOperand() (operand, error) {
+	p.skipSpace()
+	if p.done() {
+		return nil, fmt.Errorf("unexpected end of filter")
+	}
+
+	switch c := p.input[p.pos]; {
+	case c == '\'' || c == '"':
+		s, err := p.parseString(c)
+		if err != nil {
+			return nil, err
+		}
+		return func(map[string]interface{}) interface{} { return s }, nil
+	case c == '-' || c >= '0' && c <= '9':
+		start := p.pos
+		p.pos++
+		for !p.done() && strings.ContainsRune("0123456789.", rune(p.input[p.pos])) {
+			p.pos++
+		}
+		n, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", p.input[start:p.pos])
+		}
+		return func(map[string]interface{}) interface{} { return n }, nil
+	}
+
+	start := p.pos
+	for !p.done() && isIdentifierChar(p.input[p.pos]) {
+		p.pos++
+	}
+	name := p.input[start:p.pos]
+	switch name {
+	case "":
+		return nil, fmt.Errorf("unexpected %q at offset %d", p.input[p.pos:], p.pos)
+	case "true", "false":
+		b := name == "true"
+		return func(map[string]interface{}) interface{} { return b }, nil
+	case "null":
+		return func(map[string]interface{}) interface{} { return nil }, nil
+	}
+	path := strings.Split(name, ".")
+	return func(r map[string]interface{}) interface{} { return lookup(r, path) }, nil
+}
+
+// parseString reads a quoted string the way PocketBase's scanner does: a
+// quote that follows a backslash does not end the string, and \<quote> is
+// the only escape sequence.
+func (p *filterParser) parseString(quote byte) (string, error) {
+	start := p.pos + 1
+	for p.pos++; !p.done(); p.pos++ {
+		if p.input[p.pos] == quote && p.input[p.pos-1] != '\\' {
+			raw := p.input[start:p.pos]
+			p.pos++
+			return strings.ReplaceAll(raw, `\`+string(quote), string(quote)), nil
+		}
+	}
+	return "", fmt.Errorf("unterminated string")
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c == '.' || c == '@' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// lookup resolves a dotted field path, e.g. a key of a json field.
+func lookup(record map[string]interface{}, path []string) interface{} {
+	var v interface{} = record
+	for _, key := range path {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	return v
+}
+
+// compare applies op the way PocketBase does for the common cases: null
+// equals any empty value, and a multi-value field matches when any of its
+// values does.
+func compare(left interface{}, op string, right interface{}) bool {
+	if list, ok := left.([]interface{}); ok && right != nil {
+		if op == "!=" || op == "!~" {
+			for _, item := range list {
+				if !compare(item, op, right) {
+					return false
+				}
+			}
+			return true
+		}
+		for _, item := range list {
+			if compare(item, op, right) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch op {
+	case "=":
+		return equal(left, right)
+	case "!=":
+		return !equal(left, right)
+	case "~":
+		return like(left, right)
+	case "!~":
+		return !like(left, right)
+	}
+
+	c, ok := order(left, right)
+	if !ok {
+		return false
+	}
+	switch op {
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	}
+	return false
+}
+
+func equal(left, right interface{}) bool {
+	if isEmpty(left) || isEmpty(right) {
+		return isEmpty(left) && isEmpty(right)
+	}
+	c, ok := order(left, right)
+	return ok && c == 0
+}
+
+func isEmpty(v interface{}) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case string:
+		return val == ""
+	case []interface{}:
+		return len(val) == 0
+	}
+	return false
+}
+
+// order compares two values of the same kind; numbers and bools compare
+// with their string forms too, as PocketBase stores everything as text.
+func order(left, right interface{}) (int, bool) {
+	if l, ok := number(left); ok {
+		if r, ok := number(right); ok {
+			switch {
+			case l < r:
+				return -1, true
+			case l > r:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	return strings.Compare(text(left), text(right)), true
+}
+
+func number(v interface{}) (float64, bool) {
+	switch val := v.(type) {
+	case float64:
+		return val, true
+	case bool:
+		if val {
+			return 1, true
+		}
+		return 0, true
+	case string:
+		n, err := strconv.ParseFloat(val, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func text(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// like implements ~: a case-insensitive substring match, or a LIKE pattern
+// when the value contains %.
+func like(left, right interface{}) bool {
+	value, pattern := strings.ToLower(text(left)), strings.ToLower(text(right))
+	if !strings.Contains(pattern, "%") {
+		return strings.Contains(value, pattern)
+	}
+	parts := strings.Split(pattern, "%")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$").MatchString(value)
+}
@@ -0,0 +1,32 @@
+package pbtest
+
+import "testing"
+
+func TestParseFilterStrings(t *testing.T) {
+	tests := []struct {
+		filter string
+		value  string
+	}{
+		{`v = 'C:\temp'`, `C:\temp`},
+		{`v = 'O\'Brien'`, `O'Brien`},
+		{`v = "say \"hi\""`, `say "hi"`},
+		{`v = '\\' || true || \''`, `\' || true || '`},
+	}
+	for _, tt := range tests {
+		match, err := parseFilter(tt.filter)
+		if err != nil {
+			t.Errorf("parseFilter(%s): %v", tt.filter, err)
+			continue
+		}
+		if !match(map[string]interface{}{"v": tt.value}) {
+			t.Errorf("parseFilter(%s) does not match %s", tt.filter, tt.value)
+		}
+		if match(map[string]interface{}{"v": "other"}) {
+			t.Errorf("parseFilter(%s) matches other values", tt.filter)
+		}
+	}
+
+	if _, err := parseFilter(`v = 'dir\'`); err == nil {
+		t.Error(`parseFilter accepts a string whose closing quote follows a backslash`)
+	}
+}
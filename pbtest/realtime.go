@@ -0,0 +1,138 @@
+package pbtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// realtime tracks the SSE clients connected to /api/realtime.
+type realtime struct {
+	mu      sync.Mutex
+	clients map[string]*realtimeClient
+}
+
+type realtimeClient struct {
+	events        chan realtimeEvent
+	subscriptions []string
+}
+
+type realtimeEvent struct {
+	name string
+	data []byte
+}
+
+func (s *Server) connectRealtime(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "Streaming is not supported.")
+		return
+	}
+
+	id := newID()
+	client := &realtimeClient{events: make(chan realtimeEvent, 64)}
+	s.realtime.mu.Lock()
+	s.realtime.clients[id] = client
+	s.realtime.mu.Unlock()
+	defer func() {
+		s.realtime.mu.Lock()
+		delete(s.realtime.clients, id)
+		s.realtime.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	writeEvent(w, "PB_CONNECT", []byte(`{"clientId":"`+id+`"}`))
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.done:
+			return
+		case event := <-client.events:
+			writeEvent(w, event.name, event.data)
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *Server) setSubscriptions(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		ClientID      string   `json:"clientId"`
+		Subscriptions []string `json:"subscriptions"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Failed to load the submitted data due to invalid formatting.")
+		return
+	}
+
+	s.realtime.mu.Lock()
+	client := s.realtime.clients[body.ClientID]
+	if client != nil {
+		client.subscriptions = body.Subscriptions
+	}
+	s.realtime.mu.Unlock()
+
+	if client == nil {
+		writeError(w, http.StatusNotFound, "Missing or invalid client id.")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// publish notifies the subscribers of a record change. Events are dropped
+// for clients that stopped reading.
+func (s *Server) publish(c *collection, action string, record map[string]interface{}) {
+	data, err := json.Marshal(map[string]interface{}{"action": action, "record": record})
+	if err != nil {
+		return
+	}
+
+	s.realtime.mu.Lock()
+	defer s.realtime.mu.Unlock()
+	for _, client := range s.realtime.clients {
+		for _, subscription := range client.subscriptions {
+			if !subscriptionMatches(subscription, c, record) {
+				continue
+			}
+			select {
+			case client.events <- realtimeEvent{name: subscription, data: data}:
+			default:
+			}
+		}
+	}
+}
+
+// subscriptionMatches reports whether a subscription ("collection/*" or
+// "collection/id", optionally with an options query carrying a filter)
+// covers record.
+func subscriptionMatches(subscription string, c *collection, record map[string]interface{}) bool {
+	topic, rawQuery, _ := strings.Cut(subscription, "?")
+	target, id, ok := strings.Cut(topic, "/")
+	if !ok || (target != c.name && target != c.id) || (id != "*" && id != record["id"]) {
+		return false
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil || query.Get("options") == "" {
+		return err == nil
+	}
+	var options struct {
+		Query map[string]string `json:"query"`
+	}
+	if err := json.Unmarshal([]byte(query.Get("options")), &options); err != nil {
+		return false
+	}
+	match, err := parseFilter(options.Query["filter"])
+	return err == nil && match(record)
+}
+
+func writeEvent(w http.ResponseWriter, name string, data []byte) {
+	fmt.Fprintf(w, "id:%s\nevent:%s\ndata:%s\n\n", newID(), name, data)
+}
@@ -0,0 +1,245 @@
+package pbtest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// RecorderMode selects whether a Recorder talks to the server.
+type RecorderMode int
+
+const (
+	// ModeAuto replays the fixture file if it exists and records a new one
+	// otherwise; delete the file to re-record.
+	ModeAuto RecorderMode = iota
+	// ModeReplay only replays; requests missing from the fixture fail.
+	ModeReplay
+	// ModeRecord always sends requests and rewrites the fixture on Save.
+	ModeRecord
+)
+
+// Recorder is an http.RoundTripper that records the responses of a real
+// PocketBase to a fixture file and replays them in later runs, e.g. in CI:
+//
+//	rec, err := pbtest.NewRecorder("testdata/migration.json", pbtest.ModeAuto, nil)
+//	...
+//	defer rec.Save()
+//	client := gopocketbaseclient.NewClient(url, token)
+//	client.HTTPClient.Transport = rec
+//
+// Requests are matched by method, path with query and body, with the
+// random boundary of multipart bodies replaced by a fixed one; identical
+// requests replay their recorded responses in order. Request headers,
+// including Authorization, are never written to the fixture, and neither
+// are response headers carrying credentials such as Set-Cookie. Response
+// bodies are, so record auth calls against test accounts only. Responses
+// are read completely, so realtime streams cannot be recorded.
+type Recorder struct {
+	path string
+	mode RecorderMode
+	next http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []*interaction
+	used         map[*interaction]bool
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   body   `json:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   body        `json:"body,omitempty"`
+}
+
+// body is stored as text when it is valid UTF-8, which keeps JSON
+// fixtures readable and diffable, and as base64 otherwise.
+type body []byte
+
+func (b body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+	return json.Marshal(map[string]string{"base64": base64.StdEncoding.EncodeToString(b)})
+}
+
+func (b *body) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*b = body(text)
+		return nil
+	}
+	var encoded struct {
+		Base64 string `json:"base64"`
+	}
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded.Base64)
+	*b = decoded
+	return err
+}
+
+// NewRecorder returns a Recorder for the fixture at path. next sends the
+// requests being recorded; nil means http.DefaultTransport.
+func NewRecorder(path string, mode RecorderMode, next http.RoundTripper) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	r := &Recorder{path: path, mode: mode, next: next, used: make(map[*interaction]bool)}
+
+	if mode == ModeRecord {
+		return r, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && mode == ModeAuto {
+		r.mode = ModeRecord
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	if err := json.Unmarshal(data, &r.interactions); err != nil {
+		return nil, fmt.Errorf("invalid fixture %s: %w", path, err)
+	}
+	r.mode = ModeReplay
+	return r, nil
+}
+
+// Recording reports whether requests are sent to the server.
+func (r *Recorder) Recording() bool {
+	return r.mode == ModeRecord
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	recorded := recordedRequest{Method: req.Method, URL: req.URL.RequestURI(), Body: normalizeBody(req.Header, reqBody)}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, &interaction{
+		Request:  recorded,
+		Response: recordedResponse{Status: resp.StatusCode, Header: publicHeader(resp.Header), Body: respBody},
+	})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded recordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, in := range r.interactions {
+		if r.used[in] || in.Request.Method != recorded.Method || in.Request.URL != recorded.URL || !bytes.Equal(in.Request.Body, recorded.Body) {
+			continue
+		}
+		r.used[in] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.Status, http.StatusText(in.Response.Status)),
+			StatusCode:    in.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("pbtest: no recorded response for %s %s in %s", recorded.Method, recorded.URL, r.path)
+}
+
+// fixedBoundary replaces the random boundary of recorded multipart bodies.
+const fixedBoundary = "pbtest-boundary"
+
+// normalizeBody replaces the boundary of a multipart body, which changes
+// with every request, so that identical uploads match.
+func normalizeBody(header http.Header, b []byte) []byte {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") || params["boundary"] == "" {
+		return b
+	}
+	return bytes.ReplaceAll(b, []byte("--"+params["boundary"]), []byte("--"+fixedBoundary))
+}
+
+// publicHeader returns a copy of header without the headers that carry
+// credentials.
+func publicHeader(header http.Header) http.Header {
+	public := header.Clone()
+	for name := range public {
+		lower := strings.ToLower(name)
+		switch {
+		case lower == "set-cookie", lower == "authorization", lower == "proxy-authorization",
+			strings.Contains(lower, "token"), strings.Contains(lower, "secret"):
+			delete(public, name)
+		}
+	}
+	return public
+}
+
+// Save writes the recorded interactions to the fixture file, creating its
+// directory if needed. It does nothing when replaying.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to save fixture: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to save fixture: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package pbtest
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// upload sends a multipart body, which gets a new random boundary each time.
+func upload(t *testing.T, client *http.Client, url string) string {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	w.WriteField("title", "report")
+	w.Close()
+
+	resp, err := client.Post(url+"/api/collections/tasks/records", w.FormDataContentType(), &body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestRecorderReplaysMultipartAndDropsCredentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "cookie-secret"})
+		w.Header().Set("X-Auth-Token", "header-secret")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"abc"}`))
+	}))
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "fixture.json")
+
+	rec, err := NewRecorder(path, ModeAuto, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rec.Recording() {
+		t.Fatal("want recording without a fixture")
+	}
+	if got := upload(t, &http.Client{Transport: rec}, srv.URL); got != `{"id":"abc"}` {
+		t.Fatalf("recorded response = %s", got)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	fixture, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"cookie-secret", "header-secret"} {
+		if strings.Contains(string(fixture), secret) {
+			t.Errorf("fixture contains %s:\n%s", secret, fixture)
+		}
+	}
+	if !strings.Contains(string(fixture), "Content-Type") {
+		t.Errorf("fixture lost the Content-Type header:\n%s", fixture)
+	}
+
+	srv.Close()
+	rec, err = NewRecorder(path, ModeAuto, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Recording() {
+		t.Fatal("want replaying with a fixture")
+	}
+	if got := upload(t, &http.Client{Transport: rec}, srv.URL); got != `{"id":"abc"}` {
+		t.Errorf("replayed response = %s", got)
+	}
+}
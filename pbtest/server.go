@@ -0,0 +1,565 @@
+// Package pbtest provides an in-memory fake of the PocketBase API for tests
+// that would otherwise need a running PocketBase binary:
+//
+//	srv := pbtest.NewServer()
+//	defer srv.Close()
+//	if _, err := srv.Insert("tasks", map[string]interface{}{"title": "write tests"}); err != nil {
+//		t.Fatal(err)
+//	}
+//
+//	client := gopocketbaseclient.NewClient(srv.URL, "")
+//	items, err := client.GetRecords(ctx, "tasks", map[string]string{"title": "write tests"})
+//
+// The fake implements record CRUD and listing (filter, sort and paging),
+// password authentication and realtime subscriptions. Collections are
+// created on first use and have no schema; API rules, expand, field
+// selection, file uploads and the batch API are not simulated.
+package pbtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// superusersCollection is the auth collection of PocketBase superusers,
+// whose fixed ID marks admin tokens.
+const (
+	superusersCollection   = "_superusers"
+	superusersCollectionID = "pbc_3142635823"
+)
+
+// Server is a running fake PocketBase instance.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	collections map[string]*collection
+	now         func() time.Time
+	maxPerPage  int
+	realtime    realtime
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+type collection struct {
+	id        string
+	name      string
+	records   []map[string]interface{}
+	passwords map[string]string
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithClock makes the server read the time from now instead of time.Now,
+// for the created and updated fields and token expiry.
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// WithMaxPerPage caps the page size of list requests; PocketBase caps it
+// at 1000, which is the default.
+func WithMaxPerPage(n int) Option {
+	return func(s *Server) {
+		s.maxPerPage = n
+	}
+}
+
+// NewServer starts a fake PocketBase with no collections.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		collections: make(map[string]*collection),
+		now:         time.Now,
+		maxPerPage:  1000,
+		realtime:    realtime{clients: make(map[string]*realtimeClient)},
+		done:        make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/health", s.health)
+	mux.HandleFunc("GET /api/collections/{collection}/records", s.listRecords)
+	mux.HandleFunc("POST /api/collections/{collection}/records", s.createRecord)
+	mux.HandleFunc("GET /api/collections/{collection}/records/{id}", s.getRecord)
+	mux.HandleFunc("PATCH /api/collections/{collection}/records/{id}", s.updateRecord)
+	mux.HandleFunc("DELETE /api/collections/{collection}/records/{id}", s.deleteRecord)
+	mux.HandleFunc("POST /api/collections/{collection}/auth-with-password", s.authWithPassword)
+	mux.HandleFunc("GET /api/realtime", s.connectRealtime)
+	mux.HandleFunc("POST /api/realtime", s.setSubscriptions)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "The requested resource wasn't found.")
+	})
+
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Close disconnects realtime clients and shuts the server down.
+func (s *Server) Close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+	s.Server.Close()
+}
+
+// Insert stores records in collection as if they had been created through
+// the API, filling in id, created and updated unless set, and returns the
+// stored records. A "password" field is kept as the record's password
+// instead of a field. It stops at the first record whose id is taken.
+func (s *Server) Insert(collectionName string, records ...map[string]interface{}) ([]map[string]interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := make([]map[string]interface{}, len(records))
+	for i, record := range records {
+		created, err := s.create(collectionName, record)
+		if err != nil {
+			return stored[:i], fmt.Errorf("pbtest: %s: %w", collectionName, err)
+		}
+		stored[i] = created
+	}
+	return stored, nil
+}
+
+// AddUser creates a record in the auth collection that AuthWithPassword
+// accepts with email and password. Use the "_superusers" collection for an
+// admin.
+func (s *Server) AddUser(collectionName, email, password string) (map[string]interface{}, error) {
+	stored, err := s.Insert(collectionName, map[string]interface{}{"email": email, "password": password})
+	if err != nil {
+		return nil, err
+	}
+	return stored[0], nil
+}
+
+// Records returns a copy of the records of collection in creation order.
+func (s *Server) Records(collectionName string) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.collections[collectionName]
+	if c == nil {
+		return nil
+	}
+	records := make([]map[string]interface{}, len(c.records))
+	for i, record := range c.records {
+		records[i] = clone(record)
+	}
+	return records
+}
+
+// Reset deletes all collections.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.collections = make(map[string]*collection)
+}
+
+func (s *Server) health(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"code": 200, "message": "API is healthy.", "data": map[string]interface{}{}})
+}
+
+func (s *Server) listRecords(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	match, err := parseFilter(query.Get("filter"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid filter parameters.")
+		return
+	}
+	page := positiveInt(query.Get("page"), 1)
+	perPage := positiveInt(query.Get("perPage"), 30)
+
+	s.mu.Lock()
+	if s.maxPerPage > 0 && perPage > s.maxPerPage {
+		perPage = s.maxPerPage
+	}
+	var items []map[string]interface{}
+	if c := s.lookup(r.PathValue("collection")); c != nil {
+		for _, record := range c.records {
+			if match(record) {
+				items = append(items, clone(record))
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	sortRecords(items, query.Get("sort"))
+
+	total := len(items)
+	start := (page - 1) * perPage
+	if start > total {
+		start = total
+	}
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+
+	totalItems, totalPages := total, (total+perPage-1)/perPage
+	if query.Get("skipTotal") == "1" || query.Get("skipTotal") == "true" {
+		totalItems, totalPages = -1, -1
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"page":       page,
+		"perPage":    perPage,
+		"totalItems": totalItems,
+		"totalPages": totalPages,
+		"items":      append([]map[string]interface{}{}, items[start:end]...),
+	})
+}
+
+func (s *Server) getRecord(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	_, record := s.find(r.PathValue("collection"), r.PathValue("id"))
+	var out map[string]interface{}
+	if record != nil {
+		out = clone(record)
+	}
+	s.mu.Unlock()
+
+	if out == nil {
+		writeError(w, http.StatusNotFound, "The requested resource wasn't found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) createRecord(w http.ResponseWriter, r *http.Request) {
+	var body map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Failed to load the submitted data due to invalid formatting.")
+		return
+	}
+
+	s.mu.Lock()
+	record, err := s.create(r.PathValue("collection"), body)
+	s.mu.Unlock()
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Failed to create record.")
+		return
+	}
+	writeJSON(w, http.StatusOK, record)
+}
+
+func (s *Server) updateRecord(w http.ResponseWriter, r *http.Request) {
+	var body map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Failed to load the submitted data due to invalid formatting.")
+		return
+	}
+
+	s.mu.Lock()
+	c, record := s.find(r.PathValue("collection"), r.PathValue("id"))
+	var out map[string]interface{}
+	if record != nil {
+		for key, value := range body {
+			switch key {
+			case "id", "collectionId", "collectionName", "created", "updated", "passwordConfirm", "oldPassword":
+			case "password":
+				c.passwords[record["id"].(string)], _ = value.(string)
+			default:
+				setField(record, key, value)
+			}
+		}
+		record["updated"] = s.timestamp()
+		out = clone(record)
+		s.publish(c, "update", out)
+	}
+	s.mu.Unlock()
+
+	if out == nil {
+		writeError(w, http.StatusNotFound, "The requested resource wasn't found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) deleteRecord(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	c, record := s.find(r.PathValue("collection"), r.PathValue("id"))
+	if record != nil {
+		id := record["id"].(string)
+		for i, existing := range c.records {
+			if existing["id"] == id {
+				c.records = append(c.records[:i], c.records[i+1:]...)
+				break
+			}
+		}
+		delete(c.passwords, id)
+		s.publish(c, "delete", clone(record))
+	}
+	s.mu.Unlock()
+
+	if record == nil {
+		writeError(w, http.StatusNotFound, "The requested resource wasn't found.")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) authWithPassword(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Identity string `json:"identity"`
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Failed to load the submitted data due to invalid formatting.")
+		return
+	}
+
+	s.mu.Lock()
+	var out map[string]interface{}
+	var collectionID string
+	expires := s.now().Add(time.Hour)
+	if c := s.lookup(r.PathValue("collection")); c != nil {
+		for _, record := range c.records {
+			id := record["id"].(string)
+			if (record["email"] == body.Identity || record["username"] == body.Identity) && c.passwords[id] == body.Password {
+				out, collectionID = clone(record), c.id
+				break
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	if out == nil {
+		writeError(w, http.StatusBadRequest, "Failed to authenticate.")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"token":  token(out["id"].(string), collectionID, expires),
+		"record": out,
+	})
+}
+
+// create stores a new record; the caller holds s.mu.
+func (s *Server) create(collectionName string, data map[string]interface{}) (map[string]interface{}, error) {
+	c := s.collection(collectionName)
+
+	record := make(map[string]interface{}, len(data)+5)
+	for key, value := range data {
+		record[key] = value
+	}
+	id, _ := record["id"].(string)
+	if id == "" {
+		id = newID()
+	} else if _, existing := s.find(collectionName, id); existing != nil {
+		return nil, errDuplicateID
+	}
+
+	timestamp := s.timestamp()
+	record["id"] = id
+	record["collectionId"] = c.id
+	record["collectionName"] = c.name
+	if _, ok := record["created"]; !ok {
+		record["created"] = timestamp
+	}
+	if _, ok := record["updated"]; !ok {
+		record["updated"] = timestamp
+	}
+	if password, ok := record["password"].(string); ok {
+		c.passwords[id] = password
+	}
+	delete(record, "password")
+	delete(record, "passwordConfirm")
+
+	c.records = append(c.records, record)
+	out := clone(record)
+	s.publish(c, "create", out)
+	return out, nil
+}
+
+var errDuplicateID = errors.New("a record with this id already exists")
+
+// collection returns the collection given by name or ID, creating it on
+// first use; the caller holds s.mu.
+func (s *Server) collection(name string) *collection {
+	c := s.lookup(name)
+	if c == nil {
+		id := "pbc_" + strconv.FormatUint(uint64(crc32.ChecksumIEEE([]byte(name))), 10)
+		if name == superusersCollection {
+			id = superusersCollectionID
+		}
+		c = &collection{id: id, name: name, passwords: make(map[string]string)}
+		s.collections[name] = c
+	}
+	return c
+}
+
+// lookup returns the collection given by name or ID, or nil; the caller
+// holds s.mu.
+func (s *Server) lookup(nameOrID string) *collection {
+	if c := s.collections[nameOrID]; c != nil {
+		return c
+	}
+	for _, c := range s.collections {
+		if c.id == nameOrID {
+			return c
+		}
+	}
+	return nil
+}
+
+// find looks a record up by ID in the collection given by name or ID; the
+// caller holds s.mu.
+func (s *Server) find(nameOrID, id string) (*collection, map[string]interface{}) {
+	c := s.lookup(nameOrID)
+	if c == nil {
+		return nil, nil
+	}
+	for _, record := range c.records {
+		if record["id"] == id {
+			return c, record
+		}
+	}
+	return c, nil
+}
+
+// clone returns a copy of record safe to hand out of the lock.
+func clone(record map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(record))
+	for key, value := range record {
+		out[key] = value
+	}
+	return out
+}
+
+// setField applies a field update, including PocketBase's "field+" and
+// "field-" modifiers for numbers and multi-value fields.
+func setField(record map[string]interface{}, key string, value interface{}) {
+	switch {
+	case strings.HasSuffix(key, "+"):
+		field := strings.TrimSuffix(key, "+")
+		switch current := record[field].(type) {
+		case float64:
+			n, _ := value.(float64)
+			record[field] = current + n
+		case []interface{}:
+			record[field] = append(append([]interface{}{}, current...), asList(value)...)
+		default:
+			record[field] = value
+		}
+	case strings.HasSuffix(key, "-"):
+		field := strings.TrimSuffix(key, "-")
+		switch current := record[field].(type) {
+		case float64:
+			n, _ := value.(float64)
+			record[field] = current - n
+		case []interface{}:
+			remove := asList(value)
+			kept := []interface{}{}
+			for _, item := range current {
+				if !containsValue(remove, item) {
+					kept = append(kept, item)
+				}
+			}
+			record[field] = kept
+		}
+	default:
+		record[key] = value
+	}
+}
+
+func asList(value interface{}) []interface{} {
+	if list, ok := value.([]interface{}); ok {
+		return list
+	}
+	return []interface{}{value}
+}
+
+func containsValue(list []interface{}, value interface{}) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sortRecords orders records by a PocketBase sort expression such as
+// "-created,title".
+func sortRecords(records []map[string]interface{}, expr string) {
+	if expr == "" {
+		return
+	}
+	fields := strings.Split(expr, ",")
+	sort.SliceStable(records, func(i, j int) bool {
+		for _, field := range fields {
+			field = strings.TrimSpace(field)
+			desc := strings.HasPrefix(field, "-")
+			field = strings.TrimLeft(field, "+-")
+			path := strings.Split(field, ".")
+
+			c, _ := order(lookup(records[i], path), lookup(records[j], path))
+			if c != 0 {
+				return c < 0 != desc
+			}
+		}
+		return false
+	})
+}
+
+func positiveInt(s string, def int) int {
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return def
+	}
+	return n
+}
+
+// timestamp formats the current time like PocketBase datetime fields; the
+// caller holds s.mu.
+func (s *Server) timestamp() string {
+	return s.now().UTC().Format("2006-01-02 15:04:05.000Z")
+}
+
+const idAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// newID returns a random 15 character ID like the ones PocketBase generates.
+func newID() string {
+	b := make([]byte, 15)
+	for i := range b {
+		b[i] = idAlphabet[rand.IntN(len(idAlphabet))]
+	}
+	return string(b)
+}
+
+// token returns an unsigned JWT carrying the claims PocketBase puts into
+// auth tokens, valid until expires.
+func token(id, collectionID string, expires time.Time) string {
+	claims, _ := json.Marshal(map[string]interface{}{
+		"id":           id,
+		"collectionId": collectionID,
+		"type":         "auth",
+		"refreshable":  true,
+		"exp":          expires.Unix(),
+	})
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	return header + "." + base64.RawURLEncoding.EncodeToString(claims) + ".pbtest"
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{"code": status, "message": message, "data": map[string]interface{}{}})
+}
@@ -0,0 +1,127 @@
+package pbtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func getJSON(t *testing.T, rawURL string, v interface{}) int {
+	t.Helper()
+	resp, err := http.Get(rawURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode
+}
+
+type listResponse struct {
+	Page       int                      `json:"page"`
+	PerPage    int                      `json:"perPage"`
+	TotalItems int                      `json:"totalItems"`
+	Items      []map[string]interface{} `json:"items"`
+}
+
+func TestInsertDuplicateID(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	record := map[string]interface{}{"id": "abc000000000001"}
+	if _, err := srv.Insert("tasks", record); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := srv.Insert("tasks", map[string]interface{}{"title": "new"}, record)
+	if !errors.Is(err, errDuplicateID) {
+		t.Fatalf("err = %v, want errDuplicateID", err)
+	}
+	if len(stored) != 1 {
+		t.Errorf("got %d stored records, want the one before the duplicate", len(stored))
+	}
+}
+
+func TestListRecords(t *testing.T) {
+	srv := NewServer(WithMaxPerPage(2))
+	defer srv.Close()
+	for _, title := range []string{"c", "a", "b", "d"} {
+		if _, err := srv.Insert("tasks", map[string]interface{}{"title": title, "done": title != "d"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	query := url.Values{"filter": {"done = true"}, "sort": {"-title"}, "perPage": {"10"}}
+	var list listResponse
+	if status := getJSON(t, srv.URL+"/api/collections/tasks/records?"+query.Encode(), &list); status != http.StatusOK {
+		t.Fatalf("status = %d", status)
+	}
+	if list.PerPage != 2 || list.TotalItems != 3 || len(list.Items) != 2 {
+		t.Fatalf("got perPage %d, totalItems %d, %d items; want 2, 3, 2", list.PerPage, list.TotalItems, len(list.Items))
+	}
+	if list.Items[0]["title"] != "c" || list.Items[1]["title"] != "b" {
+		t.Errorf("items = %v, want c, b", list.Items)
+	}
+}
+
+func TestListRecordsByCollectionID(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	stored, err := srv.Insert("tasks", map[string]interface{}{"title": "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var list listResponse
+	getJSON(t, srv.URL+"/api/collections/"+stored[0]["collectionId"].(string)+"/records", &list)
+	if len(list.Items) != 1 || list.Items[0]["title"] != "a" {
+		t.Errorf("items = %v, want the record of tasks", list.Items)
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	srv := NewServer(WithClock(func() time.Time { return now }))
+	defer srv.Close()
+
+	user, err := srv.AddUser("users", "test@example.com", "password123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user["created"] != "2024-03-01 12:30:00.000Z" || user["updated"] != user["created"] {
+		t.Errorf("created = %v, updated = %v", user["created"], user["updated"])
+	}
+
+	resp, err := http.Post(srv.URL+"/api/collections/users/auth-with-password", "application/json",
+		strings.NewReader(`{"identity":"test@example.com","password":"password123"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var auth struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&auth); err != nil {
+		t.Fatal(err)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.Split(auth.Token, ".")[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatal(err)
+	}
+	if want := now.Add(time.Hour).Unix(); claims.Exp != want {
+		t.Errorf("exp = %d, want %d", claims.Exp, want)
+	}
+}
@@ -0,0 +1,34 @@
+package gopocketbaseclient
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// maxPooledBuffer caps the size of buffers returned to bufferPool, so a
+// single huge response does not stay pinned in memory.
+const maxPooledBuffer = 1 << 20
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// readBody reads r through a pooled buffer and returns an exactly sized
+// copy, instead of the repeated growing of io.ReadAll.
+func readBody(r io.Reader) ([]byte, error) {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			bufferPool.Put(buf)
+		}
+	}()
+
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	return bytes.Clone(buf.Bytes()), nil
+}
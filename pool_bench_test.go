@@ -0,0 +1,49 @@
+package gopocketbaseclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func BenchmarkReadBody(b *testing.B) {
+	data := bytes.Repeat([]byte(`{"id":"abc","title":"pooled"},`), 100<<10/30)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := readBody(bytes.NewReader(data)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadBodyReadAll(b *testing.B) {
+	data := bytes.Repeat([]byte(`{"id":"abc","title":"pooled"},`), 100<<10/30)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := io.ReadAll(bytes.NewReader(data)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSendListResponse(b *testing.B) {
+	list := benchList(300)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(list)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "")
+	ctx := context.Background()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.Send(ctx, "POST", "/api/collections/tasks/records", map[string]string{"title": "a"}, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
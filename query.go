@@ -0,0 +1,147 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// QueryOption adds query parameters to a list request. It is a RequestOption,
+// so query and request options can be mixed in a single call.
+type QueryOption = RequestOption
+
+// WithFilter sets a raw PocketBase filter expression.
+func WithFilter(filter string) QueryOption {
+	return func(o *requestOptions) {
+		if filter != "" {
+			o.query.Set("filter", filter)
+		}
+	}
+}
+
+// WithSort sets the sort order, e.g. WithSort("-created", "title").
+func WithSort(fields ...string) QueryOption {
+	return func(o *requestOptions) {
+		o.query.Set("sort", strings.Join(fields, ","))
+	}
+}
+
+// WithFields limits the returned fields, e.g. WithFields("id", "name").
+func WithFields(fields ...string) QueryOption {
+	return func(o *requestOptions) {
+		o.query.Set("fields", strings.Join(fields, ","))
+	}
+}
+
+// Excerpt renders the :excerpt field modifier for WithFields, returning a
+// plain-text excerpt of at most maxLength characters of a rich text field
+// instead of its full content, e.g. WithFields("id", Excerpt("body", 200, true)).
+func Excerpt(field string, maxLength int, withEllipsis bool) string {
+	return fmt.Sprintf("%s:excerpt(%d,%t)", field, maxLength, withEllipsis)
+}
+
+// WithExpand expands the given relation fields. Nested relations are
+// separated by dots ("project_id.owner_id") and back-relations use
+// PocketBase's <collection>_via_<field> form ("tasks_via_project"). Paths
+// deeper than MaxExpandDepth or otherwise malformed fail the request before
+// it is sent (see ValidateExpand). Without relations it does nothing.
+func WithExpand(relations ...string) QueryOption {
+	return func(o *requestOptions) {
+		if len(relations) == 0 {
+			return
+		}
+		expand := strings.Join(relations, ",")
+		if err := ValidateExpand(expand); err != nil && o.err == nil {
+			o.err = err
+		}
+		o.query.Set("expand", expand)
+	}
+}
+
+// WithPage selects the page to fetch (1-based).
+func WithPage(page int) QueryOption {
+	return func(o *requestOptions) {
+		o.query.Set("page", strconv.Itoa(page))
+	}
+}
+
+// WithPerPage sets the number of records per page.
+func WithPerPage(perPage int) QueryOption {
+	return func(o *requestOptions) {
+		o.query.Set("perPage", strconv.Itoa(perPage))
+	}
+}
+
+// WithSkipTotal skips the total counts query; TotalItems and TotalPages are
+// returned as -1.
+func WithSkipTotal() QueryOption {
+	return func(o *requestOptions) {
+		o.query.Set("skipTotal", "1")
+	}
+}
+
+// GetList fetches a single page of records together with the pagination totals.
+func (c *Client) GetList(ctx context.Context, collection string, page, perPage int, opts ...RequestOption) (*PaginatedResponse, error) {
+	opts = append(opts[:len(opts):len(opts)], WithPage(page), WithPerPage(perPage))
+
+	endpoint := "/api/collections/" + collection + "/records"
+	respBody, err := c.doRequest(ctx, "GET", endpoint, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var list PaginatedResponse
+	err = json.Unmarshal(respBody, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+// DefaultBatchSize is the page size used by GetFullList when batchSize <= 0.
+const DefaultBatchSize = 500
+
+// GetFullList fetches every record matching opts by walking the pages of the
+// collection batchSize records at a time, avoiding PocketBase's per-request caps.
+// Totals are skipped: the walk ends on the first page shorter than the page
+// size PocketBase reports, which may be smaller than batchSize.
+func (c *Client) GetFullList(ctx context.Context, collection string, batchSize int, opts ...RequestOption) (*JSONItems, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	opts = append(opts[:len(opts):len(opts)], WithSkipTotal())
+
+	var items []json.RawMessage
+	for page := 1; ; page++ {
+		list, err := c.GetList(ctx, collection, page, batchSize, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch page %d: %w", page, err)
+		}
+
+		var pageItems []json.RawMessage
+		err = json.Unmarshal(list.Items, &pageItems)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, pageItems...)
+
+		// PocketBase caps perPage, so a short page is measured against the
+		// page size it actually used, not batchSize.
+		if len(pageItems) == 0 || len(pageItems) < list.PerPage || (list.TotalPages >= 0 && page >= list.TotalPages) {
+			break
+		}
+	}
+
+	if items == nil {
+		items = []json.RawMessage{}
+	}
+	raw, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONItems{Items: raw}, nil
+}
@@ -0,0 +1,15 @@
+package gopocketbaseclient
+
+import "testing"
+
+func TestWithExpand(t *testing.T) {
+	o := newRequestOptions([]RequestOption{WithExpand()})
+	if o.err != nil || o.query.Has("expand") {
+		t.Errorf("WithExpand() = %q, %v; want no expand and no error", o.query.Get("expand"), o.err)
+	}
+
+	o = newRequestOptions([]RequestOption{WithExpand("project", "tags_via_task")})
+	if o.err != nil || o.query.Get("expand") != "project,tags_via_task" {
+		t.Errorf("WithExpand(...) = %q, %v", o.query.Get("expand"), o.err)
+	}
+}
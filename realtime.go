@@ -0,0 +1,392 @@
+package gopocketbaseclient
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RecordEvent is a realtime change notification for a record.
+type RecordEvent struct {
+	Action string          `json:"action"`
+	Record json.RawMessage `json:"record"`
+}
+
+// SubscribeOptions narrows a realtime subscription on the server side
+// (PocketBase 0.23+), e.g. to watch only active tasks with their project
+// expanded instead of filtering client-side.
+type SubscribeOptions struct {
+	Filter  string
+	Expand  string
+	Fields  string
+	Query   map[string]string
+	Headers map[string]string
+}
+
+// key returns the subscription string sent to PocketBase, which is also the
+// name of the SSE events delivered for it.
+func (o *SubscribeOptions) key(topic string) string {
+	if o == nil {
+		return topic
+	}
+
+	query := map[string]string{}
+	for k, v := range o.Query {
+		query[k] = v
+	}
+	if o.Filter != "" {
+		query["filter"] = o.Filter
+	}
+	if o.Expand != "" {
+		query["expand"] = o.Expand
+	}
+	if o.Fields != "" {
+		query["fields"] = o.Fields
+	}
+	if len(query) == 0 && len(o.Headers) == 0 {
+		return topic
+	}
+
+	options := map[string]interface{}{}
+	if len(query) > 0 {
+		options["query"] = query
+	}
+	if len(o.Headers) > 0 {
+		options["headers"] = o.Headers
+	}
+	data, _ := json.Marshal(options)
+
+	sep := "?"
+	if strings.Contains(topic, "?") {
+		sep = "&"
+	}
+	return topic + sep + "options=" + url.QueryEscape(string(data))
+}
+
+// Subscribe listens for realtime changes on topic ("collection/*" for a whole
+// collection or "collection/RECORD_ID" for a single record). All
+// subscriptions of a client share one SSE connection, which is reconnected
+// automatically. The subscription ends when the returned function is called
+// or ctx is done.
+func (c *Client) Subscribe(ctx context.Context, topic string, opts *SubscribeOptions, callback func(RecordEvent)) (func(), error) {
+	return c.realtimeConn().subscribe(ctx, opts.key(topic), callback)
+}
+
+func (c *Client) realtimeConn() *realtime {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.realtime == nil {
+		c.realtime = &realtime{client: c, listeners: map[string]map[uint64]func(RecordEvent){}}
+	}
+	return c.realtime
+}
+
+type realtime struct {
+	client *Client
+
+	mu        sync.Mutex
+	clientID  string
+	connected chan struct{}
+	cancel    context.CancelFunc
+	listeners map[string]map[uint64]func(RecordEvent)
+	hooks     map[uint64]func()
+	nextID    uint64
+}
+
+// onConnect registers fn to be called from the reader goroutine whenever
+// the SSE connection is (re)established and the subscriptions have been
+// submitted. Events sent while disconnected are lost, so callers use it to
+// catch up. fn must not block.
+func (r *realtime) onConnect(fn func()) func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	id := r.nextID
+	if r.hooks == nil {
+		r.hooks = map[uint64]func(){}
+	}
+	r.hooks[id] = fn
+	return func() {
+		r.mu.Lock()
+		delete(r.hooks, id)
+		r.mu.Unlock()
+	}
+}
+
+func (r *realtime) subscribe(ctx context.Context, key string, callback func(RecordEvent)) (func(), error) {
+	r.mu.Lock()
+	r.nextID++
+	id := r.nextID
+	if r.listeners[key] == nil {
+		r.listeners[key] = map[uint64]func(RecordEvent){}
+	}
+	r.listeners[key][id] = callback
+	if r.cancel == nil {
+		connCtx, cancel := context.WithCancel(context.Background())
+		r.cancel = cancel
+		r.connected = make(chan struct{})
+		go r.run(connCtx, r.connected)
+	}
+	connected := r.connected
+	r.mu.Unlock()
+
+	var once sync.Once
+	done := make(chan struct{})
+	unsubscribe := func() {
+		once.Do(func() {
+			close(done)
+			r.unsubscribe(key, id)
+		})
+	}
+
+	select {
+	case <-connected:
+	case <-ctx.Done():
+		unsubscribe()
+		return nil, ctx.Err()
+	}
+
+	if err := r.submit(ctx); err != nil {
+		unsubscribe()
+		return nil, err
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			unsubscribe()
+		case <-done:
+		}
+	}()
+
+	return unsubscribe, nil
+}
+
+func (r *realtime) unsubscribe(key string, id uint64) {
+	r.mu.Lock()
+	delete(r.listeners[key], id)
+	if len(r.listeners[key]) > 0 {
+		r.mu.Unlock()
+		return
+	}
+	delete(r.listeners, key)
+
+	if len(r.listeners) == 0 && r.cancel != nil {
+		r.cancel()
+		r.cancel = nil
+		r.clientID = ""
+		r.mu.Unlock()
+		return
+	}
+	r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_ = r.submit(ctx)
+}
+
+// submit sends the full list of active subscriptions to PocketBase.
+func (r *realtime) submit(ctx context.Context) error {
+	r.mu.Lock()
+	clientID := r.clientID
+	keys := make([]string, 0, len(r.listeners))
+	for key := range r.listeners {
+		keys = append(keys, key)
+	}
+	r.mu.Unlock()
+
+	if clientID == "" {
+		return nil
+	}
+	sort.Strings(keys)
+
+	payload := map[string]interface{}{
+		"clientId":      clientID,
+		"subscriptions": keys,
+	}
+	_, err := r.client.doRequest(ctx, "POST", "/api/realtime", payload)
+	if err != nil {
+		return fmt.Errorf("failed to submit realtime subscriptions: %w", err)
+	}
+	return nil
+}
+
+// run keeps one connection alive until ctx is cancelled. connected is
+// closed once the first stream is established; run replaces it with a new
+// channel of its own for every reconnect. Shared state is only changed
+// under r.mu after checking ctx, since a cancelled run may still be reading
+// while a new one already owns r.connected and r.clientID.
+func (r *realtime) run(ctx context.Context, connected chan struct{}) {
+	delay := 500 * time.Millisecond
+	for {
+		established := r.listen(ctx, connected)
+
+		r.mu.Lock()
+		if ctx.Err() != nil {
+			r.mu.Unlock()
+			return
+		}
+		r.clientID = ""
+		if established {
+			connected = make(chan struct{})
+			r.connected = connected
+			delay = 500 * time.Millisecond
+		}
+		r.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.client.after(delay):
+		}
+		if delay < 30*time.Second {
+			delay *= 2
+		}
+	}
+}
+
+// listen reads the SSE stream until it fails and reports whether the
+// connection was established, in which case it closed ch.
+func (r *realtime) listen(ctx context.Context, ch chan struct{}) bool {
+	resp, err := r.client.doStream(ctx, "GET", "/api/realtime", nil, "", WithHeader("Accept", "text/event-stream"))
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	connected := false
+	reader := bufio.NewReader(resp.Body)
+	for {
+		event, data, err := readSSEEvent(reader)
+		if err != nil {
+			return connected
+		}
+
+		if event == "PB_CONNECT" {
+			var msg struct {
+				ClientID string `json:"clientId"`
+			}
+			if json.Unmarshal(data, &msg) != nil || msg.ClientID == "" {
+				continue
+			}
+
+			r.mu.Lock()
+			if ctx.Err() != nil {
+				r.mu.Unlock()
+				return connected
+			}
+			r.clientID = msg.ClientID
+			alreadyConnected := connected
+			hooks := make([]func(), 0, len(r.hooks))
+			for _, hook := range r.hooks {
+				hooks = append(hooks, hook)
+			}
+			r.mu.Unlock()
+
+			if !alreadyConnected {
+				connected = true
+				_ = r.submit(ctx)
+				close(ch)
+				for _, hook := range hooks {
+					hook()
+				}
+			}
+			continue
+		}
+
+		var recordEvent RecordEvent
+		if json.Unmarshal(data, &recordEvent) != nil {
+			continue
+		}
+		r.dispatch(event, recordEvent)
+	}
+}
+
+func (r *realtime) dispatch(key string, event RecordEvent) {
+	r.mu.Lock()
+	callbacks := make([]func(RecordEvent), 0, len(r.listeners[key]))
+	for _, callback := range r.listeners[key] {
+		callbacks = append(callbacks, callback)
+	}
+	r.mu.Unlock()
+
+	for _, callback := range callbacks {
+		callback(event)
+	}
+}
+
+func readSSEEvent(reader *bufio.Reader) (string, []byte, error) {
+	var event string
+	var data []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF && line == "" {
+				return "", nil, io.ErrUnexpectedEOF
+			}
+			if err != io.EOF {
+				return "", nil, err
+			}
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if event == "" && len(data) == 0 {
+				continue
+			}
+			return event, []byte(strings.Join(data, "\n")), nil
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+}
+
+// Watch is a channel-first alternative to Subscribe: it delivers every change
+// of collection on the returned channel, which is closed once ctx is done.
+// The channel is buffered, but a consumer that stops reading eventually
+// blocks delivery for all subscriptions of the client.
+func (c *Client) Watch(ctx context.Context, collection string, opts *SubscribeOptions) (<-chan RecordEvent, error) {
+	events := make(chan RecordEvent, 64)
+	var mu sync.Mutex
+	closed := false
+
+	unsubscribe, err := c.Subscribe(ctx, collection+"/*", opts, func(e RecordEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		if closed {
+			return
+		}
+		select {
+		case events <- e:
+		case <-ctx.Done():
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		<-ctx.Done()
+		unsubscribe()
+		mu.Lock()
+		closed = true
+		close(events)
+		mu.Unlock()
+	}()
+
+	return events, nil
+}
@@ -0,0 +1,44 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ashkenazi1/gopocketbaseclient/pbtest"
+)
+
+func TestSubscribeAfterLastUnsubscribe(t *testing.T) {
+	srv := pbtest.NewServer()
+	defer srv.Close()
+	client := NewClient(srv.URL, "")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	for i := 0; i < 20; i++ {
+		unsubscribe, err := client.Subscribe(ctx, "tasks/*", nil, func(RecordEvent) {})
+		if err != nil {
+			t.Fatal(err)
+		}
+		unsubscribe()
+	}
+
+	events := make(chan RecordEvent, 1)
+	unsubscribe, err := client.Subscribe(ctx, "tasks/*", nil, func(e RecordEvent) { events <- e })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unsubscribe()
+	if _, err := srv.Insert("tasks", map[string]interface{}{"title": "a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case e := <-events:
+		if e.Action != "create" {
+			t.Errorf("action = %s, want create", e.Action)
+		}
+	case <-ctx.Done():
+		t.Fatal("no event after resubscribing")
+	}
+}
@@ -0,0 +1,184 @@
+package gopocketbaseclient
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// DefaultRecordCacheEntries is the number of records a RecordCache holds
+// unless changed with SetMaxEntries.
+const DefaultRecordCacheEntries = 1000
+
+// RecordCache is a read-through cache of a single collection's records that
+// is kept fresh by realtime events: updates replace the cached copy and
+// deletes evict it. Entries also expire after the configured TTL in case
+// events are missed while the realtime connection is down. Only records read
+// through Get are cached, and the least recently used ones are dropped once
+// the cache is full.
+type RecordCache struct {
+	client      *Client
+	collection  string
+	ttl         time.Duration
+	opts        []RequestOption
+	unsubscribe func()
+
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	lru        *list.List
+	maxEntries int
+	// generation is bumped by every event and invalidation, so a read that
+	// started before one does not store what may be a stale copy.
+	generation uint64
+}
+
+type recordCacheEntry struct {
+	id      string
+	data    json.RawMessage
+	expires time.Time
+}
+
+// NewRecordCache creates a cache for collection and subscribes it to realtime
+// changes. The subscription lives until Close is called or ctx is done. opts
+// are sent with every read, so that all cached records have the same shape;
+// their query parameters, e.g. from WithExpand or WithFields, are also sent
+// with the subscription so that events carry records of that shape.
+func (c *Client) NewRecordCache(ctx context.Context, collection string, ttl time.Duration, opts ...RequestOption) (*RecordCache, error) {
+	rc := &RecordCache{
+		client:     c,
+		collection: collection,
+		ttl:        ttl,
+		opts:       opts,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		maxEntries: DefaultRecordCacheEntries,
+	}
+
+	var subscribe *SubscribeOptions
+	if query := newRequestOptions(opts).query; len(query) > 0 {
+		subscribe = &SubscribeOptions{Query: make(map[string]string, len(query))}
+		for key := range query {
+			subscribe.Query[key] = query.Get(key)
+		}
+	}
+	unsubscribe, err := c.Subscribe(ctx, collection+"/*", subscribe, rc.apply)
+	if err != nil {
+		return nil, err
+	}
+	rc.unsubscribe = unsubscribe
+	return rc, nil
+}
+
+// Get returns the raw JSON of a record, from the cache when possible. Decode
+// it with UnmarshalPocketBaseJSON.
+func (rc *RecordCache) Get(ctx context.Context, id string) (json.RawMessage, error) {
+	rc.mu.Lock()
+	if elem, ok := rc.entries[id]; ok {
+		entry := elem.Value.(*recordCacheEntry)
+		if rc.client.now().Before(entry.expires) {
+			rc.lru.MoveToFront(elem)
+			rc.mu.Unlock()
+			return entry.data, nil
+		}
+	}
+	generation := rc.generation
+	rc.mu.Unlock()
+
+	endpoint := "/api/collections/" + rc.collection + "/records/" + id
+	respBody, err := rc.client.doRequest(ctx, "GET", endpoint, nil, rc.opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	rc.mu.Lock()
+	if rc.generation == generation {
+		rc.store(id, respBody)
+	}
+	rc.mu.Unlock()
+	return respBody, nil
+}
+
+// SetMaxEntries changes the number of records the cache holds, dropping the
+// least recently used ones if it holds more.
+func (rc *RecordCache) SetMaxEntries(n int) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if n <= 0 {
+		n = DefaultRecordCacheEntries
+	}
+	rc.maxEntries = n
+	rc.trim()
+}
+
+// Invalidate evicts a single record.
+func (rc *RecordCache) Invalidate(id string) {
+	rc.mu.Lock()
+	rc.generation++
+	rc.remove(id)
+	rc.mu.Unlock()
+}
+
+// Len returns the number of cached records, including expired ones.
+func (rc *RecordCache) Len() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return len(rc.entries)
+}
+
+// Close stops listening for realtime changes and clears the cache.
+func (rc *RecordCache) Close() {
+	rc.unsubscribe()
+	rc.mu.Lock()
+	rc.generation++
+	rc.entries = make(map[string]*list.Element)
+	rc.lru.Init()
+	rc.mu.Unlock()
+}
+
+func (rc *RecordCache) apply(event RecordEvent) {
+	var record struct {
+		ID string `json:"id"`
+	}
+	if json.Unmarshal(event.Record, &record) != nil || record.ID == "" {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.generation++
+	switch event.Action {
+	case "update":
+		if _, ok := rc.entries[record.ID]; ok {
+			rc.store(record.ID, event.Record)
+		}
+	case "delete":
+		rc.remove(record.ID)
+	}
+}
+
+// store caches data for id; rc.mu must be held.
+func (rc *RecordCache) store(id string, data json.RawMessage) {
+	expires := rc.client.now().Add(rc.ttl)
+	if elem, ok := rc.entries[id]; ok {
+		elem.Value = &recordCacheEntry{id: id, data: data, expires: expires}
+		rc.lru.MoveToFront(elem)
+		return
+	}
+	rc.entries[id] = rc.lru.PushFront(&recordCacheEntry{id: id, data: data, expires: expires})
+	rc.trim()
+}
+
+func (rc *RecordCache) remove(id string) {
+	if elem, ok := rc.entries[id]; ok {
+		rc.lru.Remove(elem)
+		delete(rc.entries, id)
+	}
+}
+
+func (rc *RecordCache) trim() {
+	for rc.lru.Len() > rc.maxEntries {
+		rc.remove(rc.lru.Back().Value.(*recordCacheEntry).id)
+	}
+}
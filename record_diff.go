@@ -0,0 +1,225 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+)
+
+// CollectionDiff is the result of DiffCollections. Records are identified by
+// the value of the key field.
+type CollectionDiff struct {
+	Collection   string
+	KeyField     string
+	OnlyInSource []string
+	OnlyInDest   []string
+	Different    []RecordDiff
+	// Same counts the records that match on both instances.
+	Same int
+	// DuplicateKeys are keys held by more than one record on either
+	// instance; those records are not compared.
+	DuplicateKeys []string
+	// SourceWithoutKey and DestWithoutKey list the IDs of the records whose
+	// key field is missing or empty; they are not compared either.
+	SourceWithoutKey []string
+	DestWithoutKey   []string
+}
+
+// RecordDiff lists the fields whose values differ between the instances.
+type RecordDiff struct {
+	Key    string
+	Fields []string
+}
+
+// IsEmpty reports whether both instances hold the same records.
+func (d *CollectionDiff) IsEmpty() bool {
+	return len(d.OnlyInSource) == 0 && len(d.OnlyInDest) == 0 && len(d.Different) == 0 &&
+		len(d.DuplicateKeys) == 0 && len(d.SourceWithoutKey) == 0 && len(d.DestWithoutKey) == 0
+}
+
+// DiffOptions configures DiffCollections.
+type DiffOptions struct {
+	// KeyField matches records between the instances; defaults to "id".
+	KeyField string
+	// IDs and Relations map the source IDs in relation fields to
+	// destination IDs before comparing, for migrations that did not
+	// preserve IDs. Relations maps field names to the collection they point
+	// to, as in MigrationConfig.Relations.
+	IDs       *IDMap
+	Relations map[string]string
+	// FileFields are compared by the names the files were uploaded with.
+	// PocketBase adds a random suffix to every stored file, so copies of
+	// the same file have different names.
+	FileFields []string
+	// RequestOptions, such as WithFilter, apply to both instances.
+	RequestOptions []RequestOption
+}
+
+// DiffCollections compares the records of a collection of c with the
+// collection of the same name on dest. System fields (created, updated, ...)
+// are ignored, so a migration that did not preserve IDs can be verified with
+// a business key. Relation and file fields still differ after such a
+// migration unless DiffOptions maps them. The destination records are held
+// in memory while the source is streamed.
+func (c *Client) DiffCollections(ctx context.Context, dest *Client, collection string, opts DiffOptions) (*CollectionDiff, error) {
+	keyField := opts.KeyField
+	if keyField == "" {
+		keyField = "id"
+	}
+	diff := &CollectionDiff{Collection: collection, KeyField: keyField}
+	duplicates := make(map[string]bool)
+
+	destRecords := make(map[string]map[string]interface{})
+	err := forEachRecord(ctx, dest, collection, opts.RequestOptions, func(record map[string]interface{}) {
+		key, ok := diffKey(record, keyField)
+		if !ok {
+			id, _ := record["id"].(string)
+			diff.DestWithoutKey = append(diff.DestWithoutKey, id)
+			return
+		}
+		if _, seen := destRecords[key]; seen {
+			duplicates[key] = true
+		}
+		destRecords[key] = record
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read destination %s: %w", collection, err)
+	}
+
+	// outcomes holds the comparison of every source key; they are only
+	// counted at the end, once all duplicates are known.
+	type outcome struct {
+		found  bool
+		fields []string
+	}
+	outcomes := make(map[string]outcome)
+	err = forEachRecord(ctx, c, collection, opts.RequestOptions, func(record map[string]interface{}) {
+		key, ok := diffKey(record, keyField)
+		if !ok {
+			id, _ := record["id"].(string)
+			diff.SourceWithoutKey = append(diff.SourceWithoutKey, id)
+			return
+		}
+		if _, seen := outcomes[key]; seen {
+			duplicates[key] = true
+			return
+		}
+		other, found := destRecords[key]
+		if !found || duplicates[key] {
+			outcomes[key] = outcome{found: found}
+			return
+		}
+
+		record = stripSystemFields(record)
+		if opts.IDs != nil {
+			remapRelations(record, opts.Relations, opts.IDs)
+		}
+		for _, field := range opts.FileFields {
+			record[field] = originalFilenames(record[field])
+			other[field] = originalFilenames(other[field])
+		}
+		outcomes[key] = outcome{found: true, fields: differingFields(record, other, keyField)}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source %s: %w", collection, err)
+	}
+
+	for key, out := range outcomes {
+		switch {
+		case duplicates[key]:
+		case !out.found:
+			diff.OnlyInSource = append(diff.OnlyInSource, key)
+		case len(out.fields) > 0:
+			diff.Different = append(diff.Different, RecordDiff{Key: key, Fields: out.fields})
+		default:
+			diff.Same++
+		}
+	}
+	for key := range destRecords {
+		if _, ok := outcomes[key]; !ok && !duplicates[key] {
+			diff.OnlyInDest = append(diff.OnlyInDest, key)
+		}
+	}
+	for key := range duplicates {
+		diff.DuplicateKeys = append(diff.DuplicateKeys, key)
+	}
+	sort.Strings(diff.OnlyInSource)
+	sort.Strings(diff.OnlyInDest)
+	sort.Strings(diff.DuplicateKeys)
+	sort.Slice(diff.Different, func(i, j int) bool { return diff.Different[i].Key < diff.Different[j].Key })
+	return diff, nil
+}
+
+// diffKey returns the key of record, or false when it is missing or empty.
+func diffKey(record map[string]interface{}, keyField string) (string, bool) {
+	value := record[keyField]
+	if value == nil || value == "" {
+		return "", false
+	}
+	return fmt.Sprint(value), true
+}
+
+// storedFileSuffix matches the "_" and 10 random characters PocketBase
+// inserts before the extension of stored file names.
+var storedFileSuffix = regexp.MustCompile(`_[a-z0-9]{10}(\.[^.]*)?$`)
+
+// originalFilenames strips the stored file suffix from a file field value.
+func originalFilenames(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return storedFileSuffix.ReplaceAllString(v, "$1")
+	case []interface{}:
+		names := make([]interface{}, len(v))
+		for i, item := range v {
+			names[i] = originalFilenames(item)
+		}
+		return names
+	}
+	return value
+}
+
+// forEachRecord calls fn with every record matching opts.
+func forEachRecord(ctx context.Context, c *Client, collection string, opts []RequestOption, fn func(map[string]interface{})) error {
+	var iterErr error
+	c.Iterate(ctx, collection, opts...)(func(item json.RawMessage, err error) bool {
+		if err != nil {
+			iterErr = err
+			return false
+		}
+		var record map[string]interface{}
+		if err := json.Unmarshal(item, &record); err != nil {
+			iterErr = err
+			return false
+		}
+		fn(record)
+		return true
+	})
+	return iterErr
+}
+
+// differingFields returns the sorted names of the non-system fields whose
+// values differ. The id is ignored unless it is the key.
+func differingFields(a, b map[string]interface{}, keyField string) []string {
+	a, b = stripSystemFields(a), stripSystemFields(b)
+	if keyField != "id" {
+		delete(a, "id")
+		delete(b, "id")
+	}
+
+	var fields []string
+	for name, value := range a {
+		if other, ok := b[name]; !ok || !reflect.DeepEqual(value, other) {
+			fields = append(fields, name)
+		}
+	}
+	for name := range b {
+		if _, ok := a[name]; !ok {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
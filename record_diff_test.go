@@ -0,0 +1,59 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ashkenazi1/gopocketbaseclient/pbtest"
+)
+
+func TestOriginalFilenames(t *testing.T) {
+	tests := []struct {
+		in, want interface{}
+	}{
+		{"report_a1b2c3d4e5.pdf", "report.pdf"},
+		{"notes_0123456789", "notes"},
+		{"my_file_abcdefghij.tar.gz", "my_file_abcdefghij.tar.gz"},
+		{"short_abc.png", "short_abc.png"},
+		{[]interface{}{"a_abcdefghij.png", "b_0123456789.jpg"}, []interface{}{"a.png", "b.jpg"}},
+		{nil, nil},
+	}
+	for _, tt := range tests {
+		if got := originalFilenames(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("originalFilenames(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDiffCollectionsDuplicateAndEmptyKeys(t *testing.T) {
+	source, dest := pbtest.NewServer(), pbtest.NewServer()
+	defer source.Close()
+	defer dest.Close()
+	insert := func(srv *pbtest.Server, slugs ...interface{}) {
+		for _, slug := range slugs {
+			if _, err := srv.Insert("pages", map[string]interface{}{"slug": slug, "title": "t"}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	insert(source, "a", "b", "b", "c", "d", "")
+	insert(dest, "a", "a", "c", nil, "e")
+
+	diff, err := NewClient(source.URL, "").DiffCollections(context.Background(), NewClient(dest.URL, ""), "pages", DiffOptions{KeyField: "slug"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(diff.DuplicateKeys, []string{"a", "b"}) {
+		t.Errorf("DuplicateKeys = %v, want [a b]", diff.DuplicateKeys)
+	}
+	if len(diff.SourceWithoutKey) != 1 || len(diff.DestWithoutKey) != 1 {
+		t.Errorf("SourceWithoutKey = %v, DestWithoutKey = %v, want one each", diff.SourceWithoutKey, diff.DestWithoutKey)
+	}
+	if diff.Same != 1 || !reflect.DeepEqual(diff.OnlyInSource, []string{"d"}) || !reflect.DeepEqual(diff.OnlyInDest, []string{"e"}) {
+		t.Errorf("Same = %d, OnlyInSource = %v, OnlyInDest = %v; want 1, [d], [e]", diff.Same, diff.OnlyInSource, diff.OnlyInDest)
+	}
+	if diff.IsEmpty() {
+		t.Error("IsEmpty() = true with duplicate keys")
+	}
+}
@@ -0,0 +1,134 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"fmt"
+)
+
+// relationLookupSize is the number of IDs fetched per `id in (...)` query; it
+// keeps the filter within URL length limits.
+const relationLookupSize = 50
+
+// RelationLoader resolves a relation field for records already in hand,
+// e.g. from realtime events or an earlier query made without expand. The
+// distinct IDs of all records are fetched together, 50 per query, instead of
+// once per record.
+type RelationLoader struct {
+	client     *Client
+	field      string
+	collection string
+	opts       []RequestOption
+}
+
+// NewRelationLoader returns a loader for field, a relation pointing at
+// collection. opts apply to every lookup query (WithAdminAuth, WithFields,
+// WithExpand, ...).
+func (c *Client) NewRelationLoader(field, collection string, opts ...RequestOption) *RelationLoader {
+	return &RelationLoader{client: c, field: field, collection: collection, opts: opts}
+}
+
+// Load fetches the records referenced by the field of every record and
+// attaches them under expand.<field>, shaped as PocketBase's own expand: a
+// record for single relations and a list for multi-relations. IDs that no
+// longer exist or are not visible are left out. Records referencing the same
+// ID share the attached map. The fetched records are also returned by ID.
+func (l *RelationLoader) Load(ctx context.Context, records []map[string]interface{}) (map[string]map[string]interface{}, error) {
+	seen := make(map[string]bool)
+	var ids []interface{}
+	for _, record := range records {
+		for _, id := range relationIDs(record[l.field]) {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	related, err := l.fetch(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		attachRelation(record, l.field, related)
+	}
+	return related, nil
+}
+
+func (l *RelationLoader) fetch(ctx context.Context, ids []interface{}) (map[string]map[string]interface{}, error) {
+	related := make(map[string]map[string]interface{}, len(ids))
+	for start := 0; start < len(ids); start += relationLookupSize {
+		end := start + relationLookupSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		opts := append(l.opts[:len(l.opts):len(l.opts)], WithFilter(In("id", ids[start:end]...).String()))
+		list, err := l.client.GetFullList(ctx, l.collection, relationLookupSize, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s relation: %w", l.field, err)
+		}
+
+		var items []map[string]interface{}
+		err = l.client.decode(list.Items, &items)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if id, ok := item["id"].(string); ok {
+				related[id] = item
+			}
+		}
+	}
+	return related, nil
+}
+
+// relationIDs returns the IDs held by a single or multiple relation value.
+func relationIDs(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			return []string{v}
+		}
+	case []string:
+		return v
+	case []interface{}:
+		ids := make([]string, 0, len(v))
+		for _, item := range v {
+			if id, ok := item.(string); ok && id != "" {
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	}
+	return nil
+}
+
+func attachRelation(record map[string]interface{}, field string, related map[string]map[string]interface{}) {
+	value := record[field]
+	ids := relationIDs(value)
+	if len(ids) == 0 {
+		return
+	}
+
+	expand, ok := record["expand"].(map[string]interface{})
+	if !ok {
+		expand = make(map[string]interface{})
+		record["expand"] = expand
+	}
+
+	if _, single := value.(string); single {
+		if item, ok := related[ids[0]]; ok {
+			expand[field] = item
+		}
+		return
+	}
+
+	items := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		if item, ok := related[id]; ok {
+			items = append(items, item)
+		}
+	}
+	expand[field] = items
+}
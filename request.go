@@ -0,0 +1,90 @@
+package gopocketbaseclient
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RequestOption customizes a single API call.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	query   url.Values
+	header  http.Header
+	timeout time.Duration
+	admin   bool
+	dump    func(RequestDump)
+
+	fileToken   bool
+	concurrency int
+	batchAPI    bool
+	batchSize   int
+	failFast    bool
+	itemRetry   *RetryPolicy
+	progress    func(done, total int)
+	dryRun      bool
+	noCache     bool
+	allowEmpty  bool
+
+	// err is an invalid option, reported before the request is sent.
+	err error
+}
+
+func newRequestOptions(opts []RequestOption) *requestOptions {
+	o := &requestOptions{query: url.Values{}, header: http.Header{}}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithAdminAuth sends the request with the client's AdminToken instead of
+// the user Token.
+func WithAdminAuth() RequestOption {
+	return func(o *requestOptions) {
+		o.admin = true
+	}
+}
+
+// WithHeader sets a header on the request, e.g. WithHeader("Accept-Language", "de").
+func WithHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.header.Set(key, value)
+	}
+}
+
+// WithQueryParam adds an arbitrary query parameter to the request.
+func WithQueryParam(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.query.Add(key, value)
+	}
+}
+
+// WithTimeout overrides the client's HTTP timeout for this request only; it
+// may be longer or shorter than the client-wide value.
+func WithTimeout(timeout time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = timeout
+	}
+}
+
+func (c *Client) httpClientFor(o *requestOptions) *http.Client {
+	if o.timeout <= 0 {
+		return c.HTTPClient
+	}
+	hc := *c.HTTPClient
+	hc.Timeout = o.timeout
+	return &hc
+}
+
+func withQuery(endpoint string, q url.Values) string {
+	if len(q) == 0 {
+		return endpoint
+	}
+	if strings.Contains(endpoint, "?") {
+		return endpoint + "&" + q.Encode()
+	}
+	return endpoint + "?" + q.Encode()
+}
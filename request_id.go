@@ -0,0 +1,49 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// RequestIDHeader carries the ID correlating a request with PocketBase's
+// logs (or those of a proxy in front of it).
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// ContextWithRequestID makes requests sent with ctx use id as their request
+// ID, e.g. to propagate the ID of the incoming request being served.
+// Otherwise every call gets a random ID.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the ID set with ContextWithRequestID.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// setRequestID picks the request ID of a call, unless one was set with
+// WithHeader. Retries of the call share it.
+func (o *requestOptions) setRequestID(ctx context.Context) {
+	if o.header.Get(RequestIDHeader) != "" {
+		return
+	}
+	id := RequestIDFromContext(ctx)
+	if id == "" {
+		id = newRequestID()
+	}
+	o.header.Set(RequestIDHeader, id)
+}
+
+func (o *requestOptions) requestID() string {
+	return o.header.Get(RequestIDHeader)
+}
+
+func newRequestID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,263 @@
+package gopocketbaseclient
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultResponseCacheEntries is the size limit used when
+// ResponseCache.MaxEntries is 0.
+const DefaultResponseCacheEntries = 1000
+
+// ResponseCache configures the client's in-memory cache of record reads,
+// enabled with WithResponseCache. Responses are cached per URL, token and
+// request headers, so users never see each other's results, and writes made
+// through the client evict the cached reads of the collection written to.
+type ResponseCache struct {
+	// TTL is how long responses stay fresh; 0 caches only the collections
+	// listed in CollectionTTL.
+	TTL time.Duration
+	// CollectionTTL overrides TTL per collection; a zero value turns caching
+	// off for that collection.
+	CollectionTTL map[string]time.Duration
+	// StaleWhileRevalidate keeps serving an expired response for this long
+	// while it is refreshed in the background.
+	StaleWhileRevalidate time.Duration
+	// MaxEntries caps the number of cached responses; the least recently
+	// used ones are dropped first.
+	MaxEntries int
+}
+
+func (rc ResponseCache) ttlFor(collection string) time.Duration {
+	if ttl, ok := rc.CollectionTTL[collection]; ok {
+		return ttl
+	}
+	return rc.TTL
+}
+
+// WithResponseCache caches GET requests for records as configured by cfg.
+func WithResponseCache(cfg ResponseCache) ClientOption {
+	return func(c *Client) {
+		if cfg.MaxEntries <= 0 {
+			cfg.MaxEntries = DefaultResponseCacheEntries
+		}
+		c.cache = &responseCache{
+			cfg:        cfg,
+			entries:    make(map[string]*list.Element),
+			lru:        list.New(),
+			refreshing: make(map[string]bool),
+		}
+	}
+}
+
+// WithNoCache bypasses the response cache for this request; the fresh
+// response still replaces the cached one.
+func WithNoCache() RequestOption {
+	return func(o *requestOptions) {
+		o.noCache = true
+	}
+}
+
+// InvalidateCache drops the cached responses of collection, or all of them
+// when collection is empty.
+func (c *Client) InvalidateCache(collection string) {
+	if c.cache != nil {
+		c.cache.invalidate(collection)
+	}
+}
+
+type responseCache struct {
+	cfg ResponseCache
+
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	lru        *list.List
+	refreshing map[string]bool
+	// generation is bumped by every invalidation; a response fetched
+	// before one is not stored, as it may predate the write.
+	generation uint64
+}
+
+type cachedResponse struct {
+	key        string
+	collection string
+	body       []byte
+	expires    time.Time
+}
+
+func (rc *responseCache) get(key string, now time.Time) (body []byte, fresh, ok bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	el, found := rc.entries[key]
+	if !found {
+		return nil, false, false
+	}
+	entry := el.Value.(*cachedResponse)
+	if now.After(entry.expires.Add(rc.cfg.StaleWhileRevalidate)) {
+		rc.remove(el)
+		return nil, false, false
+	}
+	rc.lru.MoveToFront(el)
+	return bytes.Clone(entry.body), !now.After(entry.expires), true
+}
+
+func (rc *responseCache) currentGeneration() uint64 {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.generation
+}
+
+// store caches body unless the cache was invalidated since generation.
+func (rc *responseCache) store(key, collection string, body []byte, expires time.Time, generation uint64) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if generation != rc.generation {
+		return
+	}
+
+	entry := &cachedResponse{key: key, collection: collection, body: bytes.Clone(body), expires: expires}
+	if el, ok := rc.entries[key]; ok {
+		el.Value = entry
+		rc.lru.MoveToFront(el)
+		return
+	}
+	rc.entries[key] = rc.lru.PushFront(entry)
+	for rc.lru.Len() > rc.cfg.MaxEntries {
+		rc.remove(rc.lru.Back())
+	}
+}
+
+func (rc *responseCache) remove(el *list.Element) {
+	rc.lru.Remove(el)
+	delete(rc.entries, el.Value.(*cachedResponse).key)
+}
+
+func (rc *responseCache) invalidate(collection string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.generation++
+
+	for el := rc.lru.Front(); el != nil; {
+		next := el.Next()
+		if collection == "" || el.Value.(*cachedResponse).collection == collection {
+			rc.remove(el)
+		}
+		el = next
+	}
+}
+
+// startRefresh claims the background refresh of key; only one runs at a
+// time.
+func (rc *responseCache) startRefresh(key string) bool {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.refreshing[key] {
+		return false
+	}
+	rc.refreshing[key] = true
+	return true
+}
+
+func (rc *responseCache) endRefresh(key string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	delete(rc.refreshing, key)
+}
+
+// cachedRequest serves a GET through the response cache. fetch performs the
+// actual request.
+func (c *Client) cachedRequest(ctx context.Context, endpoint string, o *requestOptions, fetch func(context.Context) ([]byte, error)) ([]byte, error) {
+	collection := recordsCollection(endpoint)
+	ttl := c.cache.cfg.ttlFor(collection)
+	if collection == "" || ttl <= 0 {
+		return fetch(ctx)
+	}
+
+	key := c.cacheKey(endpoint, o)
+	if !o.noCache {
+		if body, fresh, ok := c.cache.get(key, c.now()); ok {
+			if !fresh && c.cache.startRefresh(key) {
+				generation := c.cache.currentGeneration()
+				go func() {
+					defer c.cache.endRefresh(key)
+					if body, err := fetch(context.WithoutCancel(ctx)); err == nil {
+						c.cache.store(key, collection, body, c.now().Add(ttl), generation)
+					}
+				}()
+			}
+			return body, nil
+		}
+	}
+
+	generation := c.cache.currentGeneration()
+	body, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.store(key, collection, body, c.now().Add(ttl), generation)
+	return body, nil
+}
+
+// cacheKey identifies a response by everything that can change it besides
+// the data: the endpoint, the credentials and the request headers other
+// than the per-call request ID.
+func (c *Client) cacheKey(endpoint string, o *requestOptions) string {
+	var b strings.Builder
+	if o.admin {
+		b.WriteString("admin ")
+		b.WriteString(c.AdminToken)
+	} else {
+		b.WriteString("user ")
+		b.WriteString(c.Token)
+	}
+	for _, header := range []http.Header{c.header, o.header} {
+		keys := make([]string, 0, len(header))
+		for k := range header {
+			if k != http.CanonicalHeaderKey(RequestIDHeader) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(&b, "\n%s: %q", k, header[k])
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(endpoint)
+	return b.String()
+}
+
+// invalidateAfterWrite evicts the cached reads a write to endpoint may have
+// made stale. Batch requests can touch any collection.
+func (c *Client) invalidateAfterWrite(endpoint string) {
+	if strings.HasPrefix(endpoint, "/api/batch") {
+		c.cache.invalidate("")
+		return
+	}
+	if collection := recordsCollection(endpoint); collection != "" {
+		c.cache.invalidate(collection)
+	}
+}
+
+// recordsCollection returns the collection of a records endpoint, or "" for
+// any other route.
+func recordsCollection(endpoint string) string {
+	path, _, _ := strings.Cut(endpoint, "?")
+	rest, ok := strings.CutPrefix(path, "/api/collections/")
+	if !ok {
+		return ""
+	}
+	collection, rest, _ := strings.Cut(rest, "/")
+	if rest != "records" && !strings.HasPrefix(rest, "records/") {
+		return ""
+	}
+	return collection
+}
@@ -0,0 +1,51 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResponseCacheKeyedByIdentity(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`{"id":"abc","auth":"` + r.Header.Get("Authorization") + `","lang":"` + r.Header.Get("Accept-Language") + `"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "user-token", WithResponseCache(ResponseCache{TTL: time.Minute}))
+	client.AdminToken = "admin-token"
+	ctx := context.Background()
+
+	reads := [][]RequestOption{
+		nil,
+		nil,
+		{WithAdminAuth()},
+		{WithHeader("Accept-Language", "de")},
+	}
+	for _, opts := range reads {
+		if _, err := client.GetRecord(ctx, "tasks", "abc", opts...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("got %d requests, want 3 (the repeated plain read is cached)", got)
+	}
+}
+
+func TestResponseCacheInvalidationWinsOverInflightFetch(t *testing.T) {
+	client := NewClient("http://localhost:8090", "", WithResponseCache(ResponseCache{TTL: time.Minute}))
+	cache := client.cache
+
+	generation := cache.currentGeneration()
+	cache.invalidate("tasks")
+	cache.store("key", "tasks", []byte(`{"stale":true}`), time.Now().Add(time.Minute), generation)
+
+	if _, _, ok := cache.get("key", time.Now()); ok {
+		t.Error("a response fetched before the invalidation was cached")
+	}
+}
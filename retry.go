@@ -0,0 +1,96 @@
+package gopocketbaseclient
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how doRequest retries failed requests.
+//
+// Responses with a status in RetryableStatuses are retried, as are network
+// errors. Because a POST may already have been applied when the server
+// failed, POST requests are only retried on 429 unless RetryNonIdempotent is
+// set. A Retry-After header on the response overrides the computed backoff,
+// but is still capped at MaxDelay.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry; it doubles every attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff and the server's Retry-After.
+	MaxDelay time.Duration
+	// Jitter randomly shortens each delay by up to this fraction (0-1).
+	Jitter float64
+	// RetryableStatuses lists the HTTP statuses that trigger a retry.
+	RetryableStatuses []int
+	// RetryNonIdempotent also retries POST requests on 5xx and network errors.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a policy with 3 attempts, exponential backoff
+// starting at 200ms and retries on 429 and 5xx gateway errors.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Jitter:      0.2,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+func (p *RetryPolicy) retryable(method string, err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return method != http.MethodPost || p.RetryNonIdempotent
+	}
+
+	for _, status := range p.RetryableStatuses {
+		if status != apiErr.Status {
+			continue
+		}
+		return status == http.StatusTooManyRequests || method != http.MethodPost || p.RetryNonIdempotent
+	}
+	return false
+}
+
+func (p *RetryPolicy) delay(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		if p.MaxDelay > 0 && retryAfter > p.MaxDelay {
+			return p.MaxDelay
+		}
+		return retryAfter
+	}
+
+	d := p.BaseDelay << (attempt - 1)
+	if d <= 0 || (p.MaxDelay > 0 && d > p.MaxDelay) {
+		d = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		d -= time.Duration(float64(d) * p.Jitter * rand.Float64())
+	}
+	return d
+}
+
+func (c *Client) retryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return t.Sub(c.now())
+	}
+	return 0
+}
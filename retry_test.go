@@ -0,0 +1,17 @@
+package gopocketbaseclient
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyDelayClampsRetryAfter(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	if got := p.delay(1, time.Hour); got != 5*time.Second {
+		t.Errorf("delay with Retry-After 1h = %v, want 5s", got)
+	}
+	if got := p.delay(1, 2*time.Second); got != 2*time.Second {
+		t.Errorf("delay with Retry-After 2s = %v, want 2s", got)
+	}
+}
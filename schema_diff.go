@@ -0,0 +1,181 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrSchemaMismatch is returned by a migration with CheckSchema when the
+// destination schema cannot hold the source records.
+var ErrSchemaMismatch = errors.New("schema mismatch")
+
+// SchemaDiff describes how a destination collection differs from the source
+// collection.
+type SchemaDiff struct {
+	Collection string
+	// MissingCollection is set when the destination has no such collection;
+	// every source field is then listed in MissingFields.
+	MissingCollection bool
+	// MissingFields exist only in the source, ExtraFields only in the
+	// destination.
+	MissingFields  []string
+	ExtraFields    []string
+	TypeMismatches []FieldTypeMismatch
+	OptionDiffs    []FieldOptionDiff
+}
+
+type FieldTypeMismatch struct {
+	Field      string
+	SourceType string
+	DestType   string
+}
+
+// FieldOptionDiff is a type specific setting (maxSelect, collectionId,
+// values, ...) that differs between the instances. A nil value means the
+// setting is absent on that side.
+type FieldOptionDiff struct {
+	Field  string
+	Option string
+	Source interface{}
+	Dest   interface{}
+}
+
+// IsEmpty reports whether the schemas match.
+func (d *SchemaDiff) IsEmpty() bool {
+	return !d.MissingCollection && len(d.MissingFields) == 0 && len(d.ExtraFields) == 0 &&
+		len(d.TypeMismatches) == 0 && len(d.OptionDiffs) == 0
+}
+
+func (d *SchemaDiff) String() string {
+	if d.MissingCollection {
+		return fmt.Sprintf("collection %s missing in destination", d.Collection)
+	}
+
+	var parts []string
+	if len(d.MissingFields) > 0 {
+		parts = append(parts, "missing fields: "+strings.Join(d.MissingFields, ", "))
+	}
+	for _, m := range d.TypeMismatches {
+		parts = append(parts, fmt.Sprintf("%s is %s in source but %s in destination", m.Field, m.SourceType, m.DestType))
+	}
+	if len(d.ExtraFields) > 0 {
+		parts = append(parts, "extra fields: "+strings.Join(d.ExtraFields, ", "))
+	}
+	for _, o := range d.OptionDiffs {
+		parts = append(parts, fmt.Sprintf("%s.%s differs", o.Field, o.Option))
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("collection %s matches", d.Collection)
+	}
+	return fmt.Sprintf("collection %s: %s", d.Collection, strings.Join(parts, "; "))
+}
+
+// CompareSchemas compares a collection of c with the collection of the same
+// name on dest. Reading schemas requires superuser credentials on both
+// instances, e.g. by passing WithAdminAuth().
+func (c *Client) CompareSchemas(ctx context.Context, dest *Client, collection string, opts ...RequestOption) (*SchemaDiff, error) {
+	source, err := c.GetCollection(ctx, collection, opts...)
+	if err != nil {
+		return nil, err
+	}
+	destModel, err := dest.lookupCollection(ctx, collection, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return diffSchemas(source, destModel), nil
+}
+
+// lookupCollection is GetCollection returning nil for a missing collection.
+func (c *Client) lookupCollection(ctx context.Context, idOrName string, opts ...RequestOption) (*CollectionModel, error) {
+	model, err := c.GetCollection(ctx, idOrName, opts...)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	return model, err
+}
+
+// diffSchemas compares source with dest, which is nil when missing.
+func diffSchemas(source, dest *CollectionModel) *SchemaDiff {
+	diff := &SchemaDiff{Collection: source.Name}
+	if dest == nil {
+		diff.MissingCollection = true
+		for _, field := range source.SchemaFields() {
+			diff.MissingFields = append(diff.MissingFields, field.Name)
+		}
+		return diff
+	}
+
+	destFields := make(map[string]SchemaField)
+	for _, field := range dest.SchemaFields() {
+		destFields[field.Name] = field
+	}
+
+	seen := make(map[string]bool)
+	for _, field := range source.SchemaFields() {
+		seen[field.Name] = true
+		other, ok := destFields[field.Name]
+		if !ok {
+			diff.MissingFields = append(diff.MissingFields, field.Name)
+			continue
+		}
+		if field.Type != other.Type {
+			diff.TypeMismatches = append(diff.TypeMismatches, FieldTypeMismatch{
+				Field:      field.Name,
+				SourceType: field.Type,
+				DestType:   other.Type,
+			})
+			continue
+		}
+		diff.OptionDiffs = append(diff.OptionDiffs, diffFieldOptions(field, other)...)
+	}
+	for _, field := range dest.SchemaFields() {
+		if !seen[field.Name] {
+			diff.ExtraFields = append(diff.ExtraFields, field.Name)
+		}
+	}
+	return diff
+}
+
+func diffFieldOptions(source, dest SchemaField) []FieldOptionDiff {
+	sourceOptions, destOptions := fieldOptions(source), fieldOptions(dest)
+
+	names := make([]string, 0, len(sourceOptions))
+	for name := range sourceOptions {
+		names = append(names, name)
+	}
+	for name := range destOptions {
+		if _, ok := sourceOptions[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var diffs []FieldOptionDiff
+	for _, name := range names {
+		if !reflect.DeepEqual(sourceOptions[name], destOptions[name]) {
+			diffs = append(diffs, FieldOptionDiff{
+				Field:  source.Name,
+				Option: name,
+				Source: sourceOptions[name],
+				Dest:   destOptions[name],
+			})
+		}
+	}
+	return diffs
+}
+
+// fieldOptions merges the type specific settings of both server versions.
+func fieldOptions(field SchemaField) map[string]interface{} {
+	options := make(map[string]interface{}, len(field.Options)+len(field.Extra))
+	for k, v := range field.Options {
+		options[k] = v
+	}
+	for k, v := range field.Extra {
+		options[k] = v
+	}
+	return options
+}
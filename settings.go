@@ -0,0 +1,145 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Settings mirrors /api/settings. Every section is optional so UpdateSettings
+// only changes the sections that are set. Secrets are never returned by the
+// server and come back empty.
+type Settings struct {
+	Meta    *MetaSettings    `json:"meta,omitempty"`
+	SMTP    *SMTPSettings    `json:"smtp,omitempty"`
+	S3      *S3Settings      `json:"s3,omitempty"`
+	Backups *BackupsSettings `json:"backups,omitempty"`
+	Batch   *BatchSettings   `json:"batch,omitempty"`
+	Logs    *LogsSettings    `json:"logs,omitempty"`
+
+	// RecordAuthToken and AdminAuthToken only exist before PocketBase 0.23;
+	// newer versions configure tokens per auth collection.
+	RecordAuthToken *TokenSettings `json:"recordAuthToken,omitempty"`
+	AdminAuthToken  *TokenSettings `json:"adminAuthToken,omitempty"`
+}
+
+type MetaSettings struct {
+	AppName       string `json:"appName"`
+	AppURL        string `json:"appURL"`
+	SenderName    string `json:"senderName"`
+	SenderAddress string `json:"senderAddress"`
+	HideControls  bool   `json:"hideControls"`
+}
+
+type SMTPSettings struct {
+	Enabled    bool   `json:"enabled"`
+	Host       string `json:"host"`
+	Port       int    `json:"port"`
+	Username   string `json:"username"`
+	Password   string `json:"password,omitempty"`
+	AuthMethod string `json:"authMethod"`
+	TLS        bool   `json:"tls"`
+	LocalName  string `json:"localName"`
+}
+
+type S3Settings struct {
+	Enabled        bool   `json:"enabled"`
+	Bucket         string `json:"bucket"`
+	Region         string `json:"region"`
+	Endpoint       string `json:"endpoint"`
+	AccessKey      string `json:"accessKey"`
+	Secret         string `json:"secret,omitempty"`
+	ForcePathStyle bool   `json:"forcePathStyle"`
+}
+
+type BackupsSettings struct {
+	Cron        string      `json:"cron"`
+	CronMaxKeep int         `json:"cronMaxKeep"`
+	S3          *S3Settings `json:"s3,omitempty"`
+}
+
+type BatchSettings struct {
+	Enabled     bool  `json:"enabled"`
+	MaxRequests int   `json:"maxRequests"`
+	Timeout     int   `json:"timeout"`
+	MaxBodySize int64 `json:"maxBodySize"`
+}
+
+type LogsSettings struct {
+	MaxDays   int  `json:"maxDays"`
+	MinLevel  int  `json:"minLevel"`
+	LogIP     bool `json:"logIP"`
+	LogAuthID bool `json:"logAuthId"`
+}
+
+type TokenSettings struct {
+	Secret   string `json:"secret,omitempty"`
+	Duration int64  `json:"duration"`
+}
+
+// GetSettings returns the instance settings. Requires superuser credentials.
+func (c *Client) GetSettings(ctx context.Context, opts ...RequestOption) (*Settings, error) {
+	respBody, err := c.doRequest(ctx, "GET", "/api/settings", nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settings: %w", err)
+	}
+	return decodeSettings(respBody)
+}
+
+// UpdateSettings changes the non-nil sections of settings and returns the
+// resulting instance settings.
+func (c *Client) UpdateSettings(ctx context.Context, settings Settings, opts ...RequestOption) (*Settings, error) {
+	respBody, err := c.doRequest(ctx, "PATCH", "/api/settings", settings, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update settings: %w", err)
+	}
+	return decodeSettings(respBody)
+}
+
+func decodeSettings(respBody []byte) (*Settings, error) {
+	var settings Settings
+	err := json.Unmarshal(respBody, &settings)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// Filesystems accepted by TestS3.
+const (
+	FilesystemStorage = "storage"
+	FilesystemBackups = "backups"
+)
+
+// Email templates accepted by TestEmail.
+const (
+	EmailTemplateVerification  = "verification"
+	EmailTemplatePasswordReset = "password-reset"
+	EmailTemplateEmailChange   = "email-change"
+	EmailTemplateOTP           = "otp"
+	EmailTemplateLoginAlert    = "login-alert"
+)
+
+// TestS3 checks the S3 connection of the given filesystem (FilesystemStorage
+// or FilesystemBackups).
+func (c *Client) TestS3(ctx context.Context, filesystem string, opts ...RequestOption) error {
+	payload := map[string]interface{}{"filesystem": filesystem}
+	_, err := c.doRequest(ctx, "POST", "/api/settings/test/s3", payload, opts...)
+	if err != nil {
+		return fmt.Errorf("s3 test failed: %w", err)
+	}
+	return nil
+}
+
+// TestEmail sends a test email rendered from template to toEmail.
+func (c *Client) TestEmail(ctx context.Context, toEmail, template string, opts ...RequestOption) error {
+	payload := map[string]interface{}{
+		"email":    toEmail,
+		"template": template,
+	}
+	_, err := c.doRequest(ctx, "POST", "/api/settings/test/email", payload, opts...)
+	if err != nil {
+		return fmt.Errorf("email test failed: %w", err)
+	}
+	return nil
+}
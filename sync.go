@@ -0,0 +1,377 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// ConflictStrategy decides which side wins when a record changed on both
+// instances since the last sync.
+type ConflictStrategy int
+
+const (
+	// ConflictLastWriteWins keeps the copy with the newer updated timestamp.
+	ConflictLastWriteWins ConflictStrategy = iota
+	// ConflictSourceWins always keeps the source copy.
+	ConflictSourceWins
+	// ConflictCallback lets SyncConfig.Resolve produce the record to keep.
+	ConflictCallback
+)
+
+// systemFields are managed by PocketBase and never copied between instances.
+var systemFields = []string{"collectionId", "collectionName", "created", "updated", "expand"}
+
+// SyncConfig configures SyncOnce and Sync. Records are matched by ID, so both
+// instances must share record IDs (records created during sync keep their
+// original ID).
+type SyncConfig struct {
+	Collection string
+	Strategy   ConflictStrategy
+	// Resolve is required for ConflictCallback.
+	Resolve func(source, dest map[string]interface{}) (map[string]interface{}, error)
+	// TombstoneCollection optionally names a collection on both instances
+	// holding {collection, recordId} rows for deleted records, so deletes
+	// are replicated too.
+	TombstoneCollection string
+	// Checkpoints stores the sync position; without it every run compares
+	// the whole collection.
+	Checkpoints CheckpointStore
+	BatchSize   int
+	// OnRun, when set, is called by Sync with the result of every run,
+	// including the per-record errors that do not stop it.
+	OnRun func(*SyncResult)
+}
+
+// SyncResult summarizes one sync run.
+type SyncResult struct {
+	SourceToDest int
+	DestToSource int
+	Conflicts    int
+	Deleted      int
+	Unchanged    int
+	Errors       []error
+}
+
+// Sync runs SyncOnce every interval until ctx is done.
+func (c *Client) Sync(ctx context.Context, dest *Client, cfg SyncConfig, interval time.Duration) error {
+	for {
+		result, err := c.SyncOnce(ctx, dest, cfg)
+		if err != nil {
+			return err
+		}
+		if cfg.OnRun != nil {
+			cfg.OnRun(result)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.after(interval):
+		}
+	}
+}
+
+// SyncOnce replicates changes made since the last run between c (the source)
+// and dest in both directions, using the updated timestamp to find changes.
+// Per-record failures are collected in SyncResult.Errors; the checkpoints
+// then stay at the failed records so that the next run retries them.
+func (c *Client) SyncOnce(ctx context.Context, dest *Client, cfg SyncConfig) (*SyncResult, error) {
+	if cfg.Strategy == ConflictCallback && cfg.Resolve == nil {
+		return nil, errors.New("sync: ConflictCallback requires Resolve")
+	}
+
+	sourceKey := "sync:" + cfg.Collection + ":source"
+	destKey := "sync:" + cfg.Collection + ":dest"
+	sourceSince, err := loadCheckpoint(ctx, cfg.Checkpoints, sourceKey)
+	if err != nil {
+		return nil, err
+	}
+	destSince, err := loadCheckpoint(ctx, cfg.Checkpoints, destKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceChanges, sourceMax, err := c.changedSince(ctx, cfg, sourceSince)
+	if err != nil {
+		return nil, fmt.Errorf("sync: failed to read source changes: %w", err)
+	}
+	destChanges, destMax, err := dest.changedSince(ctx, cfg, destSince)
+	if err != nil {
+		return nil, fmt.Errorf("sync: failed to read destination changes: %w", err)
+	}
+
+	// Tombstones are read before any record is copied: a record deleted on
+	// one side must not be copied back from the other side, which still
+	// lists it as changed.
+	result := &SyncResult{}
+	var sourceDeleted, destDeleted []tombstone
+	if cfg.TombstoneCollection != "" {
+		if sourceDeleted, err = c.tombstonesSince(ctx, cfg, sourceSince); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("source tombstones: %w", err))
+			sourceMax = sourceSince
+		}
+		if destDeleted, err = dest.tombstonesSince(ctx, cfg, destSince); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("destination tombstones: %w", err))
+			destMax = destSince
+		}
+	}
+	for _, deleted := range append(sourceDeleted[:len(sourceDeleted):len(sourceDeleted)], destDeleted...) {
+		delete(sourceChanges, deleted.RecordID)
+		delete(destChanges, deleted.RecordID)
+	}
+
+	for id, source := range sourceChanges {
+		destRecord, conflict := destChanges[id]
+		if !conflict {
+			if !c.syncRecord(ctx, dest, cfg.Collection, source, result, &result.SourceToDest) {
+				holdBack(&sourceMax, source["updated"])
+			}
+			continue
+		}
+
+		// Records written by the previous run are fetched again on both
+		// sides because the checkpoint filter is inclusive.
+		if recordsEqual(source, destRecord) {
+			result.Unchanged++
+			continue
+		}
+		result.Conflicts++
+		ok := true
+		winner, err := resolveConflict(cfg, source, destRecord)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("record %s: %w", id, err))
+			ok = false
+		}
+		if ok && !recordsEqual(winner, source) {
+			ok = dest.syncRecord(ctx, c, cfg.Collection, winner, result, &result.DestToSource)
+		}
+		if ok && !recordsEqual(winner, destRecord) {
+			ok = c.syncRecord(ctx, dest, cfg.Collection, winner, result, &result.SourceToDest)
+		}
+		if !ok {
+			holdBack(&sourceMax, source["updated"])
+			holdBack(&destMax, destRecord["updated"])
+		}
+	}
+	for id, destRecord := range destChanges {
+		if _, ok := sourceChanges[id]; !ok {
+			if !dest.syncRecord(ctx, c, cfg.Collection, destRecord, result, &result.DestToSource) {
+				holdBack(&destMax, destRecord["updated"])
+			}
+		}
+	}
+
+	deleteRecords(ctx, dest, cfg.Collection, sourceDeleted, result, &sourceMax)
+	deleteRecords(ctx, c, cfg.Collection, destDeleted, result, &destMax)
+
+	if err := saveCheckpoint(ctx, cfg.Checkpoints, sourceKey, sourceSince, sourceMax); err != nil {
+		return result, err
+	}
+	if err := saveCheckpoint(ctx, cfg.Checkpoints, destKey, destSince, destMax); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// holdBack lowers checkpoint to the timestamp of a record that failed to
+// sync; the checkpoint filter is inclusive, so the next run reads it again.
+func holdBack(checkpoint *string, timestamp interface{}) {
+	if t, _ := timestamp.(string); t != "" && t < *checkpoint {
+		*checkpoint = t
+	}
+}
+
+// changedSince returns the records updated at or after since, keyed by ID,
+// together with the newest updated timestamp seen.
+func (c *Client) changedSince(ctx context.Context, cfg SyncConfig, since string) (map[string]map[string]interface{}, string, error) {
+	var opts []RequestOption
+	if since != "" {
+		opts = append(opts, WithFilter(BuildFilter("updated >= {:since}", map[string]interface{}{"since": since})))
+	}
+
+	list, err := c.GetFullList(ctx, cfg.Collection, cfg.BatchSize, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var records []map[string]interface{}
+	if err := c.decode(list.Items, &records); err != nil {
+		return nil, "", err
+	}
+
+	changes := make(map[string]map[string]interface{}, len(records))
+	newest := since
+	for _, record := range records {
+		id, _ := record["id"].(string)
+		changes[id] = record
+		if updated, _ := record["updated"].(string); updated > newest {
+			newest = updated
+		}
+	}
+	return changes, newest, nil
+}
+
+// syncRecord copies record from c to target unless target already holds the
+// same data, which also suppresses echoes of the previous run's writes. It
+// reports whether the record is in sync.
+func (c *Client) syncRecord(ctx context.Context, target *Client, collection string, record map[string]interface{}, result *SyncResult, counter *int) bool {
+	id, _ := record["id"].(string)
+	existing, err := target.GetRecord(ctx, collection, id)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		result.Errors = append(result.Errors, fmt.Errorf("record %s: %w", id, err))
+		return false
+	}
+	if existing != nil && recordsEqual(existing, record) {
+		result.Unchanged++
+		return true
+	}
+
+	data := stripSystemFields(record)
+	if existing != nil {
+		err = target.UpdateRecord(ctx, collection, id, data)
+	} else {
+		err = target.CreateRecord(ctx, collection, data)
+	}
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("record %s: %w", id, err))
+		return false
+	}
+	*counter++
+	return true
+}
+
+// tombstone is a row of SyncConfig.TombstoneCollection.
+type tombstone struct {
+	RecordID string `json:"recordId"`
+	Created  string `json:"created"`
+}
+
+func (c *Client) tombstonesSince(ctx context.Context, cfg SyncConfig, since string) ([]tombstone, error) {
+	filter := Eq("collection", cfg.Collection)
+	if since != "" {
+		filter = filter.And(Gte("created", since))
+	}
+
+	list, err := c.GetFullList(ctx, cfg.TombstoneCollection, cfg.BatchSize, WithFilter(filter.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	var tombstones []tombstone
+	if err := json.Unmarshal(list.Items, &tombstones); err != nil {
+		return nil, err
+	}
+	return tombstones, nil
+}
+
+// deleteRecords replicates tombstones to target. A failed delete holds the
+// checkpoint back at its tombstone.
+func deleteRecords(ctx context.Context, target *Client, collection string, tombstones []tombstone, result *SyncResult, checkpoint *string) {
+	for _, t := range tombstones {
+		err := target.DeleteRecord(ctx, collection, t.RecordID)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			result.Errors = append(result.Errors, fmt.Errorf("record %s: %w", t.RecordID, err))
+			holdBack(checkpoint, t.Created)
+			continue
+		}
+		if err == nil {
+			result.Deleted++
+		}
+	}
+}
+
+func resolveConflict(cfg SyncConfig, source, dest map[string]interface{}) (map[string]interface{}, error) {
+	switch cfg.Strategy {
+	case ConflictSourceWins:
+		return source, nil
+	case ConflictCallback:
+		winner, err := cfg.Resolve(source, dest)
+		if err != nil {
+			return nil, err
+		}
+		if winner["id"] == nil {
+			winner["id"] = source["id"]
+		}
+		return winner, nil
+	}
+
+	sourceUpdated, _ := source["updated"].(string)
+	destUpdated, _ := dest["updated"].(string)
+	if destUpdated > sourceUpdated {
+		return dest, nil
+	}
+	return source, nil
+}
+
+func stripSystemFields(record map[string]interface{}) map[string]interface{} {
+	data := make(map[string]interface{}, len(record))
+	for k, v := range record {
+		data[k] = v
+	}
+	for _, field := range systemFields {
+		delete(data, field)
+	}
+	return data
+}
+
+// recordsEqual compares two records ignoring system fields. Numbers and
+// datetimes are compared by value, since the two clients may decode them
+// differently (UseNumber, RFC3339 from a proxy).
+func recordsEqual(a, b map[string]interface{}) bool {
+	return reflect.DeepEqual(normalizeValue(stripSystemFields(a)), normalizeValue(stripSystemFields(b)))
+}
+
+func normalizeValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			out[k] = normalizeValue(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = normalizeValue(item)
+		}
+		return out
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+	case string:
+		// PocketBase ("2006-01-02 15:04:05Z") and RFC3339 datetimes.
+		if len(v) >= len("2006-01-02 15:04:05") && v[4] == '-' && (v[10] == ' ' || v[10] == 'T') && v[13] == ':' {
+			if t, err := parsePocketBaseTime(v); err == nil {
+				return t.UTC()
+			}
+		}
+	}
+	return v
+}
+
+func loadCheckpoint(ctx context.Context, store CheckpointStore, key string) (string, error) {
+	if store == nil {
+		return "", nil
+	}
+	checkpoint, err := store.Load(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to load checkpoint %s: %w", key, err)
+	}
+	return checkpoint, nil
+}
+
+func saveCheckpoint(ctx context.Context, store CheckpointStore, key, previous, checkpoint string) error {
+	if store == nil || checkpoint == previous {
+		return nil
+	}
+	if err := store.Save(ctx, key, checkpoint); err != nil {
+		return fmt.Errorf("failed to save checkpoint %s: %w", key, err)
+	}
+	return nil
+}
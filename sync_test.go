@@ -0,0 +1,50 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ashkenazi1/gopocketbaseclient/pbtest"
+)
+
+func TestSyncOnceReplicatesSourceDeletes(t *testing.T) {
+	sourceSrv, destSrv := pbtest.NewServer(), pbtest.NewServer()
+	defer sourceSrv.Close()
+	defer destSrv.Close()
+	stored, err := sourceSrv.Insert("notes", map[string]interface{}{"text": "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := stored[0]["id"].(string)
+
+	source, dest := NewClient(sourceSrv.URL, ""), NewClient(destSrv.URL, "")
+	cfg := SyncConfig{Collection: "notes", TombstoneCollection: "tombstones", Checkpoints: NewMemoryCheckpointStore()}
+	ctx := context.Background()
+	if _, err := source.SyncOnce(ctx, dest, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(destSrv.Records("notes")); n != 1 {
+		t.Fatalf("destination has %d records after the first sync, want 1", n)
+	}
+
+	if err := source.DeleteRecord(ctx, "notes", id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sourceSrv.Insert("tombstones", map[string]interface{}{"collection": "notes", "recordId": id}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := source.SyncOnce(ctx, dest, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Errors) > 0 || result.Deleted != 1 || result.DestToSource != 0 {
+		t.Errorf("result = %+v, want one delete and nothing copied back", result)
+	}
+	if n := len(sourceSrv.Records("notes")); n != 0 {
+		t.Errorf("source has %d records, want the deleted record to stay deleted", n)
+	}
+	if n := len(destSrv.Records("notes")); n != 0 {
+		t.Errorf("destination has %d records, want the delete replicated", n)
+	}
+}
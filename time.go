@@ -0,0 +1,121 @@
+package gopocketbaseclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// PocketBaseTimeLayout is the datetime layout PocketBase uses in API responses.
+const PocketBaseTimeLayout = "2006-01-02 15:04:05.000Z"
+
+var pocketBaseTimeLayouts = []string{
+	"2006-01-02 15:04:05Z07:00",
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// PocketBaseTime is a time.Time that (un)marshals using PocketBase's datetime
+// format. Empty strings decode to the zero time and the zero time encodes as "".
+type PocketBaseTime struct {
+	time.Time
+}
+
+// TimeOutput controls how PocketBaseTime values are marshaled.
+type TimeOutput struct {
+	// Location, when set, converts values to that location and emits them as
+	// RFC3339 with an offset instead of the space-separated UTC format.
+	Location *time.Location
+	// RFC3339 emits RFC3339 ("2006-01-02T15:04:05Z") even for UTC values.
+	RFC3339 bool
+	// OmitMilliseconds drops the fractional seconds from the output.
+	OmitMilliseconds bool
+}
+
+var (
+	timeOutputMu sync.RWMutex
+	timeOutput   TimeOutput
+)
+
+// SetTimeOutput changes the package-wide output format of PocketBaseTime.
+func SetTimeOutput(o TimeOutput) {
+	timeOutputMu.Lock()
+	defer timeOutputMu.Unlock()
+	timeOutput = o
+}
+
+func currentTimeOutput() TimeOutput {
+	timeOutputMu.RLock()
+	defer timeOutputMu.RUnlock()
+	return timeOutput
+}
+
+func (o TimeOutput) format(t time.Time) string {
+	if o.Location == nil && !o.RFC3339 {
+		if o.OmitMilliseconds {
+			return t.UTC().Format("2006-01-02 15:04:05Z")
+		}
+		return t.UTC().Format(PocketBaseTimeLayout)
+	}
+
+	if o.Location != nil {
+		t = t.In(o.Location)
+	} else {
+		t = t.UTC()
+	}
+	if o.OmitMilliseconds {
+		return t.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return t.Format("2006-01-02T15:04:05.000Z07:00")
+}
+
+func parsePocketBaseTime(s string) (time.Time, error) {
+	return parsePocketBaseTimeIn(s, time.UTC)
+}
+
+// parsePocketBaseTimeIn parses s, taking values without zone information to
+// be in loc.
+func parsePocketBaseTimeIn(s string, loc *time.Location) (time.Time, error) {
+	for _, layout := range pocketBaseTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid PocketBase datetime %q", s)
+}
+
+func (t PocketBaseTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte(`""`), nil
+	}
+	return json.Marshal(currentTimeOutput().format(t.Time))
+}
+
+func (t *PocketBaseTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	parsed, err := parsePocketBaseTime(s)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+func (t PocketBaseTime) String() string {
+	return currentTimeOutput().format(t.Time)
+}
@@ -0,0 +1,97 @@
+package gopocketbaseclient
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// superusersCollectionID is the fixed ID of the _superusers collection in
+// PocketBase 0.23+.
+const superusersCollectionID = "pbc_3142635823"
+
+// TokenClaims holds the claims PocketBase puts into its auth tokens.
+type TokenClaims struct {
+	ID           string
+	CollectionID string
+	Type         string
+	Refreshable  bool
+	ExpiresAt    time.Time
+	IssuedAt     time.Time
+}
+
+// IsAdmin reports whether the token belongs to an admin/superuser.
+func (tc *TokenClaims) IsAdmin() bool {
+	return tc.Type == "admin" || tc.CollectionID == superusersCollectionID
+}
+
+// ExpiredAt reports whether the token is expired at the given time.
+func (tc *TokenClaims) ExpiredAt(now time.Time) bool {
+	return !tc.ExpiresAt.IsZero() && !now.Before(tc.ExpiresAt)
+}
+
+// DecodeToken extracts the claims from a PocketBase JWT without contacting
+// the server. The signature is NOT verified; use it for routing and expiry
+// checks, never for authorization decisions.
+func DecodeToken(token string) (*TokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("invalid token: expected 3 segments")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid token payload: %w", err)
+	}
+
+	var raw struct {
+		ID           string      `json:"id"`
+		CollectionID string      `json:"collectionId"`
+		Type         string      `json:"type"`
+		Refreshable  bool        `json:"refreshable"`
+		Exp          json.Number `json:"exp"`
+		Iat          json.Number `json:"iat"`
+	}
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return nil, fmt.Errorf("invalid token claims: %w", err)
+	}
+
+	claims := &TokenClaims{
+		ID:           raw.ID,
+		CollectionID: raw.CollectionID,
+		Type:         raw.Type,
+		Refreshable:  raw.Refreshable,
+	}
+	if claims.ExpiresAt, err = unixClaim(raw.Exp); err != nil {
+		return nil, fmt.Errorf("invalid exp claim: %w", err)
+	}
+	if claims.IssuedAt, err = unixClaim(raw.Iat); err != nil {
+		return nil, fmt.Errorf("invalid iat claim: %w", err)
+	}
+
+	return claims, nil
+}
+
+func unixClaim(n json.Number) (time.Time, error) {
+	if n == "" {
+		return time.Time{}, nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(f), 0).UTC(), nil
+}
+
+// TokenExpired reports whether the client's token is missing, malformed or
+// expired according to the client's Clock.
+func (c *Client) TokenExpired() bool {
+	claims, err := DecodeToken(c.Token)
+	if err != nil {
+		return true
+	}
+	return claims.ExpiredAt(c.now())
+}
@@ -0,0 +1,237 @@
+package gopocketbaseclient
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// transport returns the client's *http.Transport for options to tune. The
+// first call installs a clone of the transport in use, http.DefaultTransport
+// or one supplied by the caller, so that neither is modified for its other
+// users. It returns nil for custom RoundTrippers.
+func (c *Client) transport() *http.Transport {
+	var base *http.Transport
+	switch t := c.HTTPClient.Transport.(type) {
+	case nil:
+		base = http.DefaultTransport.(*http.Transport)
+	case *http.Transport:
+		if t == c.ownedTransport {
+			return t
+		}
+		base = t
+	default:
+		return nil
+	}
+	c.ownedTransport = base.Clone()
+	c.HTTPClient.Transport = c.ownedTransport
+	return c.ownedTransport
+}
+
+// WithHTTPTimeout replaces the default 10 second limit on a whole request,
+// including reading the response; 0 means no limit. WithTimeout overrides it
+// per request.
+func WithHTTPTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.HTTPClient.Timeout = timeout
+	}
+}
+
+// WithMaxIdleConnsPerHost sets how many idle connections to PocketBase are
+// kept for reuse. net/http keeps only 2 by default, which forces concurrent
+// bulk work to reconnect constantly.
+func WithMaxIdleConnsPerHost(n int) ClientOption {
+	return func(c *Client) {
+		if t := c.transport(); t != nil {
+			t.MaxIdleConnsPerHost = n
+			if t.MaxIdleConns != 0 && t.MaxIdleConns < n {
+				t.MaxIdleConns = n
+			}
+		}
+	}
+}
+
+// WithMaxConnsPerHost caps the connections open to PocketBase at once;
+// requests beyond the cap wait for a free connection. 0 means no limit.
+func WithMaxConnsPerHost(n int) ClientOption {
+	return func(c *Client) {
+		if t := c.transport(); t != nil {
+			t.MaxConnsPerHost = n
+		}
+	}
+}
+
+// WithIdleConnTimeout sets how long an idle connection is kept before it is
+// closed.
+func WithIdleConnTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		if t := c.transport(); t != nil {
+			t.IdleConnTimeout = timeout
+		}
+	}
+}
+
+// WithTLSHandshakeTimeout limits the TLS handshake of new connections.
+func WithTLSHandshakeTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		if t := c.transport(); t != nil {
+			t.TLSHandshakeTimeout = timeout
+		}
+	}
+}
+
+// WithResponseHeaderTimeout limits the wait for response headers once the
+// request is written. Unlike WithHTTPTimeout it does not cut off slow
+// downloads of large bodies.
+func WithResponseHeaderTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		if t := c.transport(); t != nil {
+			t.ResponseHeaderTimeout = timeout
+		}
+	}
+}
+
+// WithHTTP2 turns HTTP/2 on or off for https connections. It is on by
+// default; turning it off helps behind proxies with broken HTTP/2 support.
+func WithHTTP2(enabled bool) ClientOption {
+	return func(c *Client) {
+		if t := c.transport(); t != nil {
+			t.ForceAttemptHTTP2 = enabled
+			if enabled {
+				t.TLSNextProto = nil
+			} else {
+				t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+			}
+		}
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to PocketBase,
+// e.g. for servers behind an internal CA. cfg is cloned; WithRootCAs,
+// WithClientCertificate and WithInsecureSkipVerify adjust the clone.
+func WithTLSConfig(cfg *tls.Config) ClientOption {
+	return func(c *Client) {
+		if t := c.transport(); t != nil {
+			t.TLSClientConfig = cfg.Clone()
+		}
+	}
+}
+
+// WithRootCAs trusts the certificate authorities in pool instead of the
+// system roots.
+func WithRootCAs(pool *x509.CertPool) ClientOption {
+	return func(c *Client) {
+		if cfg := c.tlsConfig(); cfg != nil {
+			cfg.RootCAs = pool
+		}
+	}
+}
+
+// WithClientCertificate presents cert to servers requiring mutual TLS. Load
+// it with tls.LoadX509KeyPair.
+func WithClientCertificate(cert tls.Certificate) ClientOption {
+	return func(c *Client) {
+		if cfg := c.tlsConfig(); cfg != nil {
+			cfg.Certificates = append(cfg.Certificates, cert)
+		}
+	}
+}
+
+// WithInsecureSkipVerify accepts any server certificate. Only use it against
+// local development servers with self-signed certificates.
+func WithInsecureSkipVerify() ClientOption {
+	return func(c *Client) {
+		if cfg := c.tlsConfig(); cfg != nil {
+			cfg.InsecureSkipVerify = true
+		}
+	}
+}
+
+// tlsConfig returns the transport's TLS configuration, creating it if
+// needed, or nil for custom RoundTrippers.
+func (c *Client) tlsConfig() *tls.Config {
+	t := c.transport()
+	if t == nil {
+		return nil
+	}
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{}
+	}
+	return t.TLSClientConfig
+}
+
+// WithProxy sends all requests through the proxy at proxyURL; nil connects
+// directly. Without it the client honors HTTP_PROXY, HTTPS_PROXY and
+// NO_PROXY.
+func WithProxy(proxyURL *url.URL) ClientOption {
+	return func(c *Client) {
+		if t := c.transport(); t != nil {
+			t.Proxy = nil
+			if proxyURL != nil {
+				t.Proxy = http.ProxyURL(proxyURL)
+			}
+		}
+	}
+}
+
+// WithUnixSocket connects to PocketBase over the Unix domain socket at path,
+// e.g. when it runs as a sidecar. The host of BaseURL is then only used for
+// the Host header, so http://localhost is the usual choice.
+func WithUnixSocket(path string) ClientOption {
+	return func(c *Client) {
+		if t := c.transport(); t != nil {
+			var dialer net.Dialer
+			t.Proxy = nil
+			t.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return dialer.DialContext(ctx, "unix", path)
+			}
+		}
+	}
+}
+
+// WithCompression turns gzip compression of responses on or off. It is on
+// by default, which pays off for large list responses and exports over slow
+// links; turn it off to save CPU next to the server.
+func WithCompression(enabled bool) ClientOption {
+	return func(c *Client) {
+		if t := c.transport(); t != nil {
+			t.DisableCompression = !enabled
+		}
+	}
+}
+
+// decodedBody returns the response body, decompressed if the transport left
+// it gzip encoded; net/http only decodes transparently when it requested the
+// compression itself. Like net/http, it then drops the encoding and length
+// headers, which no longer describe the body.
+func decodedBody(resp *http.Response) (io.ReadCloser, error) {
+	if resp.Header.Get("Content-Encoding") != "gzip" {
+		return resp.Body, nil
+	}
+	zr, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress response body: %w", err)
+	}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return gzipBody{zr, resp.Body}, nil
+}
+
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
@@ -0,0 +1,26 @@
+package gopocketbaseclient
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestTransportOptionsCloneSuppliedTransport(t *testing.T) {
+	shared := &http.Transport{MaxConnsPerHost: 1}
+	client := NewClient("http://localhost:8090", "")
+	client.HTTPClient.Transport = shared
+
+	WithMaxConnsPerHost(8)(client)
+	WithIdleConnTimeout(0)(client)
+
+	if shared.MaxConnsPerHost != 1 {
+		t.Errorf("supplied transport was modified: MaxConnsPerHost = %d", shared.MaxConnsPerHost)
+	}
+	owned, ok := client.HTTPClient.Transport.(*http.Transport)
+	if !ok || owned == shared || owned.MaxConnsPerHost != 8 {
+		t.Errorf("client transport = %#v, want a clone with MaxConnsPerHost 8", client.HTTPClient.Transport)
+	}
+	if http.DefaultTransport.(*http.Transport).MaxConnsPerHost != 0 {
+		t.Error("http.DefaultTransport was modified")
+	}
+}
@@ -0,0 +1,121 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// upsertLookupSize is the number of keys looked up per filtered query; it
+// keeps the filter within URL length limits.
+const upsertLookupSize = 50
+
+// UpsertByKey creates or updates records identified by a unique business
+// key such as "sku" or "email" instead of the record ID. Existing records
+// are found with one filtered query per 50 records, then created or patched
+// with the bulk machinery (WithConcurrency, WithFailFast and WithItemRetry
+// apply). Records without a key value fail; if the same key appears twice
+// in records, both are treated the same way, so keys should be unique.
+func (c *Client) UpsertByKey(ctx context.Context, collection, keyField string, records []map[string]interface{}, opts ...RequestOption) (*BulkOperationResult, error) {
+	existing, err := c.lookupKeys(ctx, collection, keyField, records, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := "/api/collections/" + collection + "/records"
+	result := newBulkResult(len(records))
+	// Creates and updates are mixed, so retries follow the stricter POST
+	// rules.
+	err = c.runBulk(ctx, "POST", result, opts, func(ctx context.Context, i int) (string, json.RawMessage, error) {
+		key, ok := records[i][keyField]
+		if !ok || key == nil {
+			return "", nil, fmt.Errorf("record has no %s", keyField)
+		}
+
+		if id, ok := existing[upsertKey(key)]; ok {
+			respBody, err := c.doRequest(ctx, "PATCH", endpoint+"/"+id, records[i], opts...)
+			if err != nil {
+				return id, nil, fmt.Errorf("failed to update record: %w", err)
+			}
+			return id, respBody, nil
+		}
+
+		respBody, err := c.doRequest(ctx, "POST", endpoint, records[i], opts...)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to create record: %w", err)
+		}
+		return recordID(respBody), respBody, nil
+	})
+	return result, err
+}
+
+// lookupKeys maps the key values of records that exist in the collection to
+// their record IDs.
+func (c *Client) lookupKeys(ctx context.Context, collection, keyField string, records []map[string]interface{}, opts []RequestOption) (map[string]string, error) {
+	if keyField == "" {
+		return nil, errors.New("upsert: key field is required")
+	}
+
+	existing := make(map[string]string)
+	for start := 0; start < len(records); start += upsertLookupSize {
+		end := start + upsertLookupSize
+		if end > len(records) {
+			end = len(records)
+		}
+
+		var keys []interface{}
+		for _, record := range records[start:end] {
+			if key, ok := record[keyField]; ok && key != nil {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) == 0 {
+			continue
+		}
+
+		lookupOpts := append(opts[:len(opts):len(opts)], WithFilter(In(keyField, keys...).String()), WithFields("id", keyField))
+		list, err := c.GetFullList(ctx, collection, upsertLookupSize, lookupOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up existing records: %w", err)
+		}
+
+		var found []map[string]interface{}
+		if err := json.Unmarshal(list.Items, &found); err != nil {
+			return nil, err
+		}
+		for _, record := range found {
+			id, _ := record["id"].(string)
+			existing[upsertKey(record[keyField])] = id
+		}
+	}
+	return existing, nil
+}
+
+// upsertKey formats a key value for comparison. Numbers are formatted the
+// same way whatever their type, since keys decoded from the server are
+// float64 while the caller's may be ints.
+func upsertKey(key interface{}) string {
+	switch v := key.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return strconv.FormatFloat(f, 'f', -1, 64)
+		}
+		return v.String()
+	}
+
+	rv := reflect.ValueOf(key)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	}
+	return fmt.Sprint(key)
+}
@@ -0,0 +1,43 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ashkenazi1/gopocketbaseclient/pbtest"
+)
+
+func TestUpsertByKeyNumericKeys(t *testing.T) {
+	srv := pbtest.NewServer()
+	defer srv.Close()
+	if _, err := srv.Insert("products", map[string]interface{}{"sku": 1000000.0, "name": "old"}); err != nil {
+		t.Fatal(err)
+	}
+	client := NewClient(srv.URL, "")
+
+	records := []map[string]interface{}{
+		{"sku": 1000000, "name": "int"},
+		{"sku": json.Number("2000000"), "name": "new"},
+	}
+	result, err := client.UpsertByKey(context.Background(), "products", "sku", records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+
+	stored := srv.Records("products")
+	if len(stored) != 2 || stored[0]["name"] != "int" {
+		t.Errorf("records = %v, want the existing sku updated and one created", stored)
+	}
+}
+
+func TestUpsertKey(t *testing.T) {
+	for _, key := range []interface{}{1000000, int64(1000000), uint(1000000), 1e6, float32(1e6), json.Number("1e6")} {
+		if got := upsertKey(key); got != "1000000" {
+			t.Errorf("upsertKey(%T %v) = %s, want 1000000", key, key, got)
+		}
+	}
+}
@@ -1,22 +1,24 @@
 package gopocketbaseclient
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
-	"net/url"
-	"strings"
+	"net/http"
+	"sort"
 )
 
-func (c *Client) CreateRecord(collection string, record map[string]interface{}) error {
+func (c *Client) CreateRecord(ctx context.Context, collection string, record map[string]interface{}, opts ...RequestOption) error {
 	endpoint := "/api/collections/" + collection + "/records"
-	respBody, err := c.doRequest("POST", endpoint, record)
+	respBody, err := c.doRequest(ctx, "POST", endpoint, record, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to create record: %w", err)
 	}
 
 	var createdRecord map[string]interface{}
-	err = json.Unmarshal(respBody, &createdRecord)
+	err = c.decode(respBody, &createdRecord)
 	if err != nil {
 		log.Println("Error unmarshaling create record response:", err)
 		return fmt.Errorf("failed to unmarshal create record response: %w", err)
@@ -25,16 +27,32 @@ func (c *Client) CreateRecord(collection string, record map[string]interface{})
 	return nil
 }
 
-func (c *Client) GetRecords(collection string, filters map[string]string) (*JSONItems, error) {
-	var filterParts []string
-	for column, value := range filters {
-		filterParts = append(filterParts, fmt.Sprintf("%s='%s'", column, value))
+// WithAllowEmpty makes GetRecords return an empty JSONItems instead of
+// ErrNoRecords when nothing matches; use JSONItems.IsEmpty to check for it.
+func WithAllowEmpty() RequestOption {
+	return func(o *requestOptions) {
+		o.allowEmpty = true
+	}
+}
+
+// GetRecords returns the records whose columns equal the given values. It
+// returns ErrNoRecords when nothing matches, unless WithAllowEmpty is set.
+func (c *Client) GetRecords(ctx context.Context, collection string, filters map[string]string, opts ...RequestOption) (*JSONItems, error) {
+	columns := make([]string, 0, len(filters))
+	for column := range filters {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	var conditions []Filter
+	for _, column := range columns {
+		conditions = append(conditions, Eq(column, filters[column]))
 	}
-	filterString := strings.Join(filterParts, " && ")
-	encodedFilterString := url.QueryEscape(fmt.Sprintf("(%s)", filterString))
 
-	endpoint := fmt.Sprintf("/api/collections/%s/records?filter=%s", collection, encodedFilterString)
-	respBody, err := c.doRequest("GET", endpoint, nil)
+	opts = append(opts[:len(opts):len(opts)], WithFilter(fmt.Sprintf("(%s)", And(conditions...))))
+
+	endpoint := "/api/collections/" + collection + "/records"
+	respBody, err := c.doRequest(ctx, "GET", endpoint, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -45,16 +63,19 @@ func (c *Client) GetRecords(collection string, filters map[string]string) (*JSON
 		return nil, err
 	}
 
-	if len(records.Items) == 0 {
-		return nil, fmt.Errorf("no records found")
+	if records.IsEmpty() {
+		if !newRequestOptions(opts).allowEmpty {
+			return nil, ErrNoRecords
+		}
+		records.Items = json.RawMessage("[]")
 	}
 
 	return &records, nil
 }
 
-func (c *Client) All(collection string) (*JSONItems, error) {
+func (c *Client) All(ctx context.Context, collection string, opts ...RequestOption) (*JSONItems, error) {
 	endpoint := "/api/collections/" + collection + "/records"
-	respBody, err := c.doRequest("GET", endpoint, nil)
+	respBody, err := c.doRequest(ctx, "GET", endpoint, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -68,15 +89,15 @@ func (c *Client) All(collection string) (*JSONItems, error) {
 	return &data, nil
 }
 
-func (c *Client) UpdateRecord(collection, id string, record map[string]interface{}) error {
+func (c *Client) UpdateRecord(ctx context.Context, collection, id string, record map[string]interface{}, opts ...RequestOption) error {
 	endpoint := "/api/collections/" + collection + "/records/" + id
-	respBody, err := c.doRequest("PATCH", endpoint, record)
+	respBody, err := c.doRequest(ctx, "PATCH", endpoint, record, opts...)
 	if err != nil {
 		return err
 	}
 
 	var updatedRecord map[string]interface{}
-	err = json.Unmarshal(respBody, &updatedRecord)
+	err = c.decode(respBody, &updatedRecord)
 	if err != nil {
 		log.Println("Error unmarshaling response:", err)
 		return err
@@ -85,15 +106,90 @@ func (c *Client) UpdateRecord(collection, id string, record map[string]interface
 	return nil
 }
 
-func (c *Client) DeleteRecord(collection, id string) error {
+// UpdateRecordAndReturn patches a record and returns it as stored after the
+// update. record is either a map, sent as is, or a struct, encoded as
+// described for CreateRecordAndReturn so that omitempty fields are left
+// untouched.
+func (c *Client) UpdateRecordAndReturn(ctx context.Context, collection, id string, record interface{}, opts ...RequestOption) (map[string]interface{}, error) {
+	body, err := c.recordBody(record, false)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := "/api/collections/" + collection + "/records/" + id
+	respBody, err := c.doRequest(ctx, "PATCH", endpoint, body, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update record: %w", err)
+	}
+
+	var updated map[string]interface{}
+	err = c.decode(respBody, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// CreateRecordAndReturn creates a record and returns it as stored. record is
+// either a map, sent as is, or a struct such as one embedding BaseRecord.
+// Structs are encoded with MarshalPocketBaseJSON, and the system fields
+// (created, updated, collectionId, collectionName, expand) are dropped, as
+// is an empty id so that PocketBase generates one.
+func (c *Client) CreateRecordAndReturn(ctx context.Context, collection string, record interface{}, opts ...RequestOption) (map[string]interface{}, error) {
+	body, err := c.recordBody(record, true)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := "/api/collections/" + collection + "/records"
+	respBody, err := c.doRequest(ctx, "POST", endpoint, body, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create record: %w", err)
+	}
+
+	var created map[string]interface{}
+	err = c.decode(respBody, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// recordBody prepares a record given as a map or a struct for a request
+// body. Maps are sent unchanged. Structs lose their system fields and their
+// id, which is kept on create when set so that custom IDs still work.
+func (c *Client) recordBody(record interface{}, create bool) (interface{}, error) {
+	if m, ok := record.(map[string]interface{}); ok {
+		return m, nil
+	}
+
+	raw, err := pocketBaseValue(record, c.encodeOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal record: %w", err)
+	}
+	data, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("record must encode to a JSON object, got %T", record)
+	}
+
+	data = stripSystemFields(data)
+	if id, _ := data["id"].(string); !create || id == "" {
+		delete(data, "id")
+	}
+	return data, nil
+}
+
+func (c *Client) DeleteRecord(ctx context.Context, collection, id string, opts ...RequestOption) error {
 	endpoint := "/api/collections/" + collection + "/records/" + id
-	_, err := c.doRequest("DELETE", endpoint, nil)
+	_, err := c.doRequest(ctx, "DELETE", endpoint, nil, opts...)
 	return err
 }
 
-func All(c *Client, collection string) (*JSONItems, error) {
+func All(ctx context.Context, c *Client, collection string, opts ...RequestOption) (*JSONItems, error) {
 	endpoint := "/api/collections/" + collection + "/records"
-	respBody, err := c.doRequest("GET", endpoint, nil)
+	respBody, err := c.doRequest(ctx, "GET", endpoint, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -106,3 +202,75 @@ func All(c *Client, collection string) (*JSONItems, error) {
 
 	return &data, nil
 }
+
+func (c *Client) GetRecord(ctx context.Context, collection, id string, opts ...RequestOption) (map[string]interface{}, error) {
+	endpoint := "/api/collections/" + collection + "/records/" + id
+	respBody, err := c.doRequest(ctx, "GET", endpoint, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var record map[string]interface{}
+	err = c.decode(respBody, &record)
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// GetFirst returns the first record matching filter in the given sort order
+// (e.g. "-created"; empty keeps the server default). It fails with an error
+// matching ErrNotFound when nothing matches.
+func (c *Client) GetFirst(ctx context.Context, collection, filter, sortBy string, opts ...RequestOption) (map[string]interface{}, error) {
+	opts = append(opts[:len(opts):len(opts)], WithFilter(filter), WithSkipTotal())
+	if sortBy != "" {
+		opts = append(opts, WithSort(sortBy))
+	}
+
+	list, err := c.GetList(ctx, collection, 1, 1, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []map[string]interface{}
+	err = c.decode(list.Items, &records)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no %s record matches %q: %w", collection, filter, ErrNotFound)
+	}
+
+	return records[0], nil
+}
+
+// FirstOrCreate returns the first record matching filter, creating it from
+// defaults when there is none; created reports which happened. If the create
+// is rejected because a concurrent caller inserted the record first (with a
+// unique index on the filtered fields), the record is fetched once more
+// before giving up.
+func (c *Client) FirstOrCreate(ctx context.Context, collection, filter string, defaults map[string]interface{}, opts ...RequestOption) (record map[string]interface{}, created bool, err error) {
+	record, err = c.GetFirst(ctx, collection, filter, "", opts...)
+	if err == nil || !errors.Is(err, ErrNotFound) {
+		return record, false, err
+	}
+
+	endpoint := "/api/collections/" + collection + "/records"
+	respBody, err := c.doRequest(ctx, "POST", endpoint, defaults, opts...)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.Status == http.StatusBadRequest {
+			if record, getErr := c.GetFirst(ctx, collection, filter, "", opts...); getErr == nil {
+				return record, false, nil
+			}
+		}
+		return nil, false, fmt.Errorf("failed to create record: %w", err)
+	}
+
+	err = c.decode(respBody, &record)
+	if err != nil {
+		return nil, false, err
+	}
+	return record, true, nil
+}
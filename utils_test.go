@@ -0,0 +1,57 @@
+package gopocketbaseclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ashkenazi1/gopocketbaseclient/pbtest"
+)
+
+func TestGetRecordsEmpty(t *testing.T) {
+	srv := pbtest.NewServer()
+	defer srv.Close()
+	if _, err := srv.Insert("tasks", map[string]interface{}{"title": "a"}); err != nil {
+		t.Fatal(err)
+	}
+	client := NewClient(srv.URL, "")
+	ctx := context.Background()
+
+	if _, err := client.GetRecords(ctx, "tasks", map[string]string{"title": "b"}); !errors.Is(err, ErrNoRecords) {
+		t.Errorf("err = %v, want ErrNoRecords", err)
+	}
+	records, err := client.GetRecords(ctx, "tasks", map[string]string{"title": "b"}, WithAllowEmpty())
+	if err != nil || string(records.Items) != "[]" {
+		t.Errorf("WithAllowEmpty: got %v, %v; want an empty list", records, err)
+	}
+	records, err = client.GetRecords(ctx, "tasks", map[string]string{"title": "a"})
+	if err != nil || records.IsEmpty() {
+		t.Errorf("got %v, %v; want the matching record", records, err)
+	}
+}
+
+func TestRecordRoundTrip(t *testing.T) {
+	srv := pbtest.NewServer()
+	defer srv.Close()
+	client := NewClient(srv.URL, "")
+	ctx := context.Background()
+
+	created, err := client.CreateRecordAndReturn(ctx, "tasks", map[string]interface{}{"title": "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _ := created["id"].(string)
+	if err := client.UpdateRecord(ctx, "tasks", id, map[string]interface{}{"title": "b"}); err != nil {
+		t.Fatal(err)
+	}
+	record, err := client.GetRecord(ctx, "tasks", id)
+	if err != nil || record["title"] != "b" {
+		t.Fatalf("GetRecord = %v, %v; want the updated record", record, err)
+	}
+	if err := client.DeleteRecord(ctx, "tasks", id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetRecord(ctx, "tasks", id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetRecord after delete: err = %v, want ErrNotFound", err)
+	}
+}